@@ -2,18 +2,27 @@ package flashbot
 
 import (
 	"bytes"
+	"context"
 	"crypto/ecdsa"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"log"
+	"math/big"
 	"net/http"
+	"net/http/httptrace"
 	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
-	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
 )
 
@@ -35,15 +44,117 @@ const (
 	MethodEstimateGasBundle = "eth_estimateGasBundle"
 	MethodGetUserStats      = "flashbots_getUserStats"
 	MethodGetBundleStats    = "flashbots_getBundleStats"
+	MethodGetBundleStatsV2  = "flashbots_getBundleStatsV2"
+
+	// `eth_cancelBundle` withdraws the bundles sent with a replacementUuid.
+	MethodCancelBundle = "eth_cancelBundle"
+
+	// `mev_sendBundle` sends a MEV-Share bundle, whose body may reference
+	// transactions already known to the relay by hash.
+	MethodMevSendBundle = "mev_sendBundle"
+)
+
+const (
+	defaultTimeout = 20 * time.Second
+
+	// defaultMaxResponseSize caps a response body unless WithMaxResponseSize is used.
+	defaultMaxResponseSize = 4 << 20
+
+	// Connection pool defaults, sized for many concurrent requests to the
+	// single relay host instead of net/http's two idle connections per host.
+	defaultMaxIdleConns        = 64
+	defaultMaxIdleConnsPerHost = 64
+	defaultIdleConnTimeout     = 90 * time.Second
 )
 
 var (
+	defaultClient = &http.Client{Timeout: defaultTimeout, Transport: defaultTransport()}
+
 	errorTransaction = errors.New("nil")
+	errorNodeMissing = errors.New("node rpc is not configured")
+	errorNoAccount   = errors.New("no account to sign transactions with, set PrivateKey or use WithSigner")
+
+	errorEmptyResult = errors.New("relay returned an empty result")
+
+	errorMempoolWithoutMaxBlock = errors.New("useMempool requires a maxBlockNumber")
 )
 
 type FlashbotLaunch struct {
 	Rpc        string
 	PrivateKey *ecdsa.PrivateKey
+
+	// NodeRpc is an Ethereum node used for chain lookups such as the latest block.
+	NodeRpc string
+
+	network          string
+	sendBundleUUID   bool
+	maxResponseSize  int64
+	basicAuth        *basicAuth
+	defaultHints     []string
+	client           *http.Client
+	contentType      string
+	accept           string
+	logger           *log.Logger
+	debug            bool
+	logRequests      bool
+	checkTargetBlock bool
+	checkNonces      bool
+	checkMaxBlock    bool
+	submitMargin     time.Duration
+
+	chainID     *big.Int
+	scheme      SignatureScheme
+	signer      Signer
+	keys        *keyPool
+	requestHook func(RequestInfo)
+	recorder    SubmissionRecorder
+
+	replacements *replacementTracker
+	callCache    *callCache
+	limiter      *rateLimiter
+
+	fallbackRelays []string
+
+	submissions submissionCounters
+
+	// nodeChainID caches the chain ID of NodeRpc, see FetchChainID.
+	nodeChainMu sync.Mutex
+	nodeChainID *big.Int
+
+	// keySigners caches a Signer for every PrivateKey used for signing.
+	signerMu   sync.Mutex
+	keySigners map[*ecdsa.PrivateKey]*keySigner
+}
+
+type basicAuth struct {
+	user, pass string
+}
+
+// RequestInfo describes a completed relay request, see WithRequestHook.
+type RequestInfo struct {
+	Method   string
+	Relay    string
+	Signer   common.Address
+	Duration time.Duration
+	Response ResponseMeta
+	// Bundle is the UUID of the bundle sent by eth_sendBundle requests.
+	Bundle string
+}
+
+// ResponseMeta carries the HTTP status and headers of a relay response.
+type ResponseMeta struct {
+	StatusCode int
+	Header     http.Header
+}
+
+// RateLimitRemaining returns the X-RateLimit-Remaining header value, if
+// the relay sent a valid one.
+func (m ResponseMeta) RateLimitRemaining() (int, bool) {
+	remaining, err := strconv.Atoi(m.Header.Get("X-RateLimit-Remaining"))
+	if err != nil {
+		return 0, false
+	}
+	return remaining, true
 }
 
 type metaRequestParams struct {
@@ -62,12 +173,26 @@ type SendBundleParams struct {
 	MinTimestamp      int64    `json:"minTimestamp,omitempty"`
 	MaxTimestamp      int64    `json:"maxTimestamp,omitempty"`
 	RevertingTxHashes []string `json:"revertingTxHashes,omitempty"`
+	DroppingTxHashes  []string `json:"droppingTxHashes,omitempty"`
+	ReplacementUuid   string   `json:"replacementUuid,omitempty"`
+
+	Preferences *BundlePreferences `json:"preferences,omitempty"`
+
+	// signerIndex selects the pooled key signing the request, see
+	// WithSignerIndex.
+	signerIndex *int
+	// uuid is the client-side bundle UUID, reported to the request hook
+	// whether or not it is sent.
+	uuid string
 }
 
 type SendBundleResponse struct {
 	ID      uint          `json:"id"`
 	Version string        `json:"jsonrpc"`
-	Result  *bundleResult `json:"result"`
+	Result  *BundleResult `json:"result"`
+	Error   *errorResult  `json:"error"`
+	// UUID is the client-side bundle UUID, see SendBundle.
+	UUID string `json:"-"`
 }
 
 // ############
@@ -78,12 +203,17 @@ type CallBundleParams struct {
 	BlockNumber      string   `json:"blockNumber"`
 	StateBlockNumber string   `json:"stateBlockNumber"`
 	Timestamp        int64    `json:"timestamp,omitempty"`
+	IncludeLogs      bool     `json:"includeLogs,omitempty"`
+	Coinbase         string   `json:"coinbase,omitempty"`
+
+	GenerateAccessList bool  `json:"generateAccessList,omitempty"`
+	GasLimit           int64 `json:"gasLimit,omitempty"`
 }
 
 type CallBundleResponse struct {
 	ID         uint         `json:"id"`
 	Version    string       `json:"jsonrpc"`
-	Result     *callResult  `json:"result"`
+	Result     *CallResult  `json:"result"`
 	Error      *errorResult `json:"error"`
 	Raw        string
 	StatusCode int
@@ -92,28 +222,62 @@ type CallBundleResponse struct {
 // ####################
 //  PrivateTransaction
 // ####################
+// PreferenceUseMempool lets a private transaction fall back to the public
+// mempool once MaxBlockNumber has passed.
+const PreferenceUseMempool = "useMempool"
+
 type SendPrivateTx struct {
-	Transaction    string          `json:"txs"`
-	MaxBlockNumber string          `json:"maxBlockNumber"`
-	Preferences    map[string]bool `json:"preferences"`
+	Transaction      string          `json:"tx"`
+	MaxBlockNumber   string          `json:"maxBlockNumber"`
+	Preferences      map[string]bool `json:"preferences,omitempty"`
+	ReplacementNonce uint64          `json:"replacementNonce,omitempty"`
+
+	// Privacy is sent as preferences.privacy, next to the boolean Preferences.
+	Privacy *PrivacyPreferences `json:"-"`
+	// Validity is sent as preferences.validity.
+	Validity *Validity `json:"-"`
+
+	// refundPercent is the sender's refund share, see WithRefundPercent.
+	refundPercent *int
+}
+
+// Validity sets who is refunded the MEV a private transaction creates.
+type Validity struct {
+	Refund []Refund `json:"refund,omitempty"`
+}
+
+// Refund pays Percent percent of the MEV to Address.
+type Refund struct {
+	Address common.Address `json:"address"`
+	Percent int            `json:"percent"`
+}
+
+// PrivacyPreferences selects what MEV-Share reveals about a transaction.
+type PrivacyPreferences struct {
+	Hints []string `json:"hints"`
+	// OnlyBuilders restricts which builders receive the transaction,
+	// named as MEV-Share lists them, e.g. "flashbots".
+	OnlyBuilders []string `json:"builders,omitempty"`
 }
 
 type SendPrivateTxResponse struct {
-	JsonRPC string `json:"jsonrpc"`
-	Id      int    `json:"id"`
-	Result  string `json:"result"`
+	JsonRPC string       `json:"jsonrpc"`
+	Id      int          `json:"id"`
+	Result  string       `json:"result"`
+	Error   *errorResult `json:"error"`
 }
 
 // ###########
 //  userStats
 // ###########
 type UserStatsResponse struct {
-	ID      uint       `json:"id"`
-	Version string     `json:"jsonrpc"`
-	Result  *userStats `json:"result"`
+	ID      uint         `json:"id"`
+	Version string       `json:"jsonrpc"`
+	Result  *UserStats   `json:"result"`
+	Error   *errorResult `json:"error"`
 }
 
-type userStats struct {
+type UserStats struct {
 	IsHighPriority       bool   `json:"is_high_priority"`
 	AllTimeMinerPayments string `json:"all_time_miner_payments"`
 	AllTimeGasSimulated  string `json:"all_time_gas_simulated"`
@@ -124,18 +288,30 @@ type userStats struct {
 }
 
 type errorResult struct {
-	Code    int64  `json:"code"`
-	Message string `json:"message"`
+	Code    int64           `json:"code"`
+	Message string          `json:"message"`
+	Data    json.RawMessage `json:"data,omitempty"`
+}
+
+func (e *errorResult) Error() string {
+	if detail := e.dataString(); detail != "" {
+		return fmt.Sprintf("relay error %d: %s: %s", e.Code, e.Message, detail)
+	}
+	return fmt.Sprintf("relay error %d: %s", e.Code, e.Message)
 }
 
-type bundleResult struct {
+type BundleResult struct {
 	BundleHash string `json:"bundleHash"`
+	// BlockNumber is the accepted target block, only echoed by some relays.
+	BlockNumber string `json:"blockNumber,omitempty"`
+
+	extra map[string]json.RawMessage
 }
 
 // ###################
 // transaction Result
 // ###################
-type txResult struct {
+type TxResult struct {
 	CoinbaseDiff      string `json:"coinbaseDiff"`
 	EthSentToCoinbase string `json:"ethSentToCoinbase"`
 	FromAddress       string `json:"fromAddress"`
@@ -146,20 +322,38 @@ type txResult struct {
 	TxHash            string `json:"txHash"`
 	Value             string `json:"value"`
 	Error             string `json:"error,omitempty"`
+	Logs              []Log  `json:"logs,omitempty"`
+
+	// The fee caps of a dynamic fee transaction, if the relay returns them.
+	MaxFeePerGas         string `json:"maxFeePerGas,omitempty"`
+	MaxPriorityFeePerGas string `json:"maxPriorityFeePerGas,omitempty"`
+
+	// AccessList is the generated access list, see WithAccessList.
+	AccessList types.AccessList `json:"accessList,omitempty"`
 }
 
-type callResult struct {
+// Log is an event emitted by a simulated transaction.
+type Log struct {
+	Address common.Address `json:"address"`
+	Topics  []common.Hash  `json:"topics"`
+	Data    hexutil.Bytes  `json:"data"`
+}
+
+type CallResult struct {
 	BundleGasPrice    string     `json:"bundleGasPrice"`
 	BundleHash        string     `json:"bundleHash"`
 	CoinbaseDiff      string     `json:"coinbaseDiff"`
 	EthSentToCoinbase string     `json:"ethSentToCoinbase"`
 	GasFees           string     `json:"gasFees"`
-	Results           []txResult `json:"results"`
+	Results           []TxResult `json:"results"`
 	StateBlockNumber  uint64     `json:"stateBlockNumber"`
 	TotalGasUsed      uint64     `json:"totalGasUsed"`
+	// StateRoot is the state root after the bundle, returned only by
+	// relays that run their own simulation node and expose it.
+	StateRoot string `json:"stateRoot,omitempty"`
 }
 
-func New(relayRPC string) *FlashbotLaunch {
+func New(relayRPC string, opts ...Option) *FlashbotLaunch {
 
 	rpc, _ := RelayDefaultRPC(relayRPC)
 
@@ -168,36 +362,198 @@ func New(relayRPC string) *FlashbotLaunch {
 		log.Fatal("The PrivateKey is nil, please export it !")
 	}
 
-	return &FlashbotLaunch{
+	f := newClient(rpc, HexToECDSA(privateKey), opts)
+	f.setNetwork(relayRPC)
+
+	return f
+}
+
+// setNetwork records the network the client submits to and derives the
+// chain ID from it, unless WithChainID set one.
+func (f *FlashbotLaunch) setNetwork(network string) {
+	f.network = network
+	if f.chainID == nil {
+		f.chainID, _ = NetworkChainID(network)
+	}
+}
+
+func newClient(rpc string, privateKey *ecdsa.PrivateKey, opts []Option) *FlashbotLaunch {
+	f := &FlashbotLaunch{
 		Rpc:        rpc,
-		PrivateKey: HexToECDSA(privateKey),
+		PrivateKey: privateKey,
+		client:     &http.Client{Timeout: defaultTimeout, Transport: defaultTransport()},
+	}
+	for _, opt := range opts {
+		opt(f)
+	}
+	// Warn only once every option is applied, so the warning goes to the
+	// logger of WithLogger wherever it appears in opts.
+	if f.insecureTLS() {
+		f.logf("flashbot: TLS certificate verification is disabled, do not use this in production")
+	}
+
+	return f
+}
+
+// SendBundle sends the bundle for blockNumber. A blockNumber of 0 targets
+// the next block, which requires NodeRpc.
+//
+// Every bundle gets a random UUID, returned in SendBundleResponse.UUID for
+// correlating submissions. It is only sent as the bundle's replacementUuid
+// with WithBundleUUID; WithReplacementUUID sends a UUID of your own.
+func (f *FlashbotLaunch) SendBundle(transactions []string, blockNumber uint64, opts ...BundleOption) (*SendBundleResponse, error) {
+	return f.SendBundleContext(context.Background(), transactions, blockNumber, opts...)
+}
+
+// SendBundleContext is SendBundle giving up when ctx is done. A request
+// cut short fails with a *CanceledError telling whether the relay may
+// still have received the bundle.
+func (f *FlashbotLaunch) SendBundleContext(ctx context.Context, transactions []string, blockNumber uint64, opts ...BundleOption) (*SendBundleResponse, error) {
+	return f.submitBundle(ctx, transactions, blockNumber, opts)
+}
+
+// SendBundleFast sends the bundle like SendBundle but gives the relay
+// request at most deadline, so an unreachable relay fails quickly and the
+// bundle can be sent to another one instead. It does not retry.
+func (f *FlashbotLaunch) SendBundleFast(transactions []string, blockNumber uint64, deadline time.Duration, opts ...BundleOption) (*SendBundleResponse, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), deadline)
+	defer cancel()
+
+	resp, err := f.submitBundle(ctx, transactions, blockNumber, opts)
+	if err != nil && ctx.Err() != nil {
+		return resp, fmt.Errorf("relay did not answer within %s: %w", deadline, err)
+	}
+
+	return resp, err
+}
+
+func (f *FlashbotLaunch) submitBundle(ctx context.Context, transactions []string, blockNumber uint64, opts []BundleOption) (*SendBundleResponse, error) {
+	var resp *SendBundleResponse
+	uuid := newUUID()
+	blockNumber, err := f.resolveBlockContext(ctx, blockNumber)
+	if err == nil {
+		var relay string
+		resp, uuid, relay, err = f.sendBundle(ctx, transactions, blockNumber, uuid, opts)
+		if relay != "" {
+			f.submissions.count(relay, err == nil && resp.Err() == nil)
+		}
 	}
+	if f.recorder != nil {
+		f.recordSubmission(transactions, blockNumber, uuid, resp, err)
+	}
+
+	return resp, err
 }
 
-func (f *FlashbotLaunch) SendBundle(transactions []string, blockNumber uint64) (*SendBundleResponse, error) {
+// sendBundle validates and sends the bundle, returning its UUID and the
+// relay it was sent to, empty when it failed before any was tried.
+func (f *FlashbotLaunch) sendBundle(ctx context.Context, transactions []string, blockNumber uint64, uuid string, opts []BundleOption) (*SendBundleResponse, string, string, error) {
 	if len(transactions) < 1 {
-		return nil, errorTransaction
+		return nil, uuid, "", errorTransaction
+	}
+
+	if f.checkTargetBlock {
+		if err := f.validateTargetBlock(blockNumber); err != nil {
+			return nil, uuid, "", err
+		}
+	}
+
+	if f.checkNonces {
+		if err := ValidateBundleNonces(transactions); err != nil {
+			return nil, uuid, "", err
+		}
+	}
+
+	if f.submitMargin > 0 {
+		if err := f.validateSubmissionTime(blockNumber, f.submitMargin); err != nil {
+			return nil, uuid, "", err
+		}
 	}
 
 	args := SendBundleParams{
 		Transactions: transactions,
 		BlockNumber:  HextoBlockNumber(blockNumber),
 	}
+	for _, opt := range opts {
+		opt(&args)
+	}
+	if args.ReplacementUuid != "" {
+		uuid = args.ReplacementUuid
+	} else if f.sendBundleUUID {
+		args.ReplacementUuid = uuid
+	}
+	args.uuid = uuid
 
-	resp := f.requestRPC(MethodSendBundle, args)
+	if err := validateTxHashes("revertingTxHashes", args.RevertingTxHashes); err != nil {
+		return nil, uuid, "", err
+	}
+	if err := validateTxHashes("droppingTxHashes", args.DroppingTxHashes); err != nil {
+		return nil, uuid, "", err
+	}
+	if err := validateTimestampWindow(args.MinTimestamp, args.MaxTimestamp); err != nil {
+		return nil, uuid, "", err
+	}
+	if args.Preferences != nil {
+		if err := args.Preferences.validate(); err != nil {
+			return nil, uuid, "", err
+		}
+		for _, name := range args.Preferences.Builders {
+			if !knownMevShareBuilder(name) {
+				f.logf("flashbot: builder %q is not a known MEV-Share builder name", name)
+			}
+		}
+	}
+
+	var signer Signer
+	if args.signerIndex != nil {
+		pooled, err := f.pooledSigner(*args.signerIndex)
+		if err != nil {
+			return nil, uuid, "", err
+		}
+		signer = pooled
+	} else {
+		signer = f.requestSigner()
+	}
+
+	resp, relay, err := f.requestRPCFrom(ctx, signer, MethodSendBundle, args)
+	if err != nil {
+		return nil, uuid, relay, err
+	}
 	sendBundleResp := new(SendBundleResponse)
-	if err := json.Unmarshal(resp, sendBundleResp); err != nil {
-		return nil, err
+	if err := f.decodeResponse(MethodSendBundle, resp, sendBundleResp); err != nil {
+		return nil, uuid, relay, err
+	}
+	if err := sendBundleResp.CheckBlock(blockNumber); err != nil {
+		f.logf("flashbot: %v", err)
 	}
 
-	return sendBundleResp, nil
+	sendBundleResp.UUID = uuid
+
+	return sendBundleResp, uuid, relay, nil
 }
 
-func (f *FlashbotLaunch) CallBundle(transaction []string, blockNumber uint64) (*CallBundleResponse, error) {
+// CallBundle simulates the bundle for blockNumber on top of the latest
+// state. A blockNumber of 0 targets the next block, which requires NodeRpc.
+//
+// The bundle is always simulated at the top of the block, directly on the
+// state after stateBlockNumber: eth_callBundle has no parameter to place it
+// behind other transactions. To see how it performs later in a block,
+// prepend those transactions to the bundle.
+func (f *FlashbotLaunch) CallBundle(transaction []string, blockNumber uint64, opts ...CallBundleOption) (*CallBundleResponse, error) {
+	return f.CallBundleContext(context.Background(), transaction, blockNumber, opts...)
+}
+
+// CallBundleContext is CallBundle giving up when ctx is done.
+func (f *FlashbotLaunch) CallBundleContext(ctx context.Context, transaction []string, blockNumber uint64, opts ...CallBundleOption) (*CallBundleResponse, error) {
 	if len(transaction) < 1 {
 		return nil, errorTransaction
 	}
 
+	blockNumber, err := f.resolveBlockContext(ctx, blockNumber)
+	if err != nil {
+		return nil, err
+	}
+
 	args := CallBundleParams{
 		Transactions:     transaction,
 		BlockNumber:      HextoBlockNumber(blockNumber),
@@ -205,91 +561,376 @@ func (f *FlashbotLaunch) CallBundle(transaction []string, blockNumber uint64) (*
 		Timestamp:        1615920932,
 	}
 
-	resp := f.requestRPC(MethodCallBundle, args)
+	return f.callBundle(ctx, args, opts)
+}
+
+// CallBundleHistorical simulates a bundle for targetBlock on top of the state
+// at stateBlock, both given explicitly rather than relative to "latest".
+// Simulating old state needs a relay or node backed by an archive node.
+func (f *FlashbotLaunch) CallBundleHistorical(transaction []string, targetBlock, stateBlock uint64, opts ...CallBundleOption) (*CallBundleResponse, error) {
+	if len(transaction) < 1 {
+		return nil, errorTransaction
+	}
+
+	args := CallBundleParams{
+		Transactions:     transaction,
+		BlockNumber:      HextoBlockNumber(targetBlock),
+		StateBlockNumber: HextoBlockNumber(stateBlock),
+	}
+
+	return f.callBundle(context.Background(), args, opts)
+}
+
+// CallBundleRaw simulates a bundle with blockNumber and stateBlockNumber
+// passed to the relay exactly as given, e.g. "0x10d4f2a" or "latest". A
+// timestamp of 0 leaves it to the relay.
+func (f *FlashbotLaunch) CallBundleRaw(transaction []string, blockNumber, stateBlockNumber string, timestamp int64, opts ...CallBundleOption) (*CallBundleResponse, error) {
+	if len(transaction) < 1 {
+		return nil, errorTransaction
+	}
+
+	args := CallBundleParams{
+		Transactions:     transaction,
+		BlockNumber:      blockNumber,
+		StateBlockNumber: stateBlockNumber,
+		Timestamp:        timestamp,
+	}
+
+	return f.callBundle(context.Background(), args, opts)
+}
+
+func (f *FlashbotLaunch) callBundle(ctx context.Context, args CallBundleParams, opts []CallBundleOption) (*CallBundleResponse, error) {
+	for _, opt := range opts {
+		opt(&args)
+	}
+
+	if args.Coinbase != "" && !common.IsHexAddress(args.Coinbase) {
+		return nil, fmt.Errorf("invalid coinbase address %q", args.Coinbase)
+	}
+	if args.GasLimit < 0 {
+		return nil, fmt.Errorf("invalid block gas limit %d", args.GasLimit)
+	}
+
+	var cacheKey common.Hash
+	if f.callCache != nil {
+		key, err := callCacheKey(args)
+		if err != nil {
+			return nil, err
+		}
+		if cached, ok := f.callCache.get(key); ok {
+			return cached, nil
+		}
+		cacheKey = key
+	}
+
+	resp, err := f.requestRPCContext(ctx, MethodCallBundle, args)
+	if err != nil {
+		return nil, err
+	}
 	callBUndleResp := new(CallBundleResponse)
-	if err := json.Unmarshal(resp, callBUndleResp); err != nil {
+	if err := f.decodeResponse(MethodCallBundle, resp, callBUndleResp); err != nil {
 		return nil, err
 	}
 
+	// A relay rejection is a Go error; reverted transactions of a completed
+	// simulation are reported per transaction, see SimulationFailed.
+	if err := callBUndleResp.Err(); err != nil {
+		return callBUndleResp, err
+	}
+
+	if f.callCache != nil {
+		f.callCache.put(cacheKey, callBUndleResp)
+	}
+
 	return callBUndleResp, nil
 }
 
-func (f *FlashbotLaunch) SendPrivateTransaction(tx string, maxBlockNumber string) (*SendPrivateTxResponse, error) {
+func (f *FlashbotLaunch) SendPrivateTransaction(tx string, maxBlockNumber string, opts ...PrivateTxOption) (*SendPrivateTxResponse, error) {
+	return f.SendPrivateTransactionContext(context.Background(), tx, maxBlockNumber, opts...)
+}
+
+// SendPrivateTransactionContext is SendPrivateTransaction giving up when
+// ctx is done. A request cut short fails with a *CanceledError telling
+// whether the relay may still have received the transaction.
+func (f *FlashbotLaunch) SendPrivateTransactionContext(ctx context.Context, tx string, maxBlockNumber string, opts ...PrivateTxOption) (*SendPrivateTxResponse, error) {
 	args := SendPrivateTx{
 		Transaction:    tx,
 		MaxBlockNumber: maxBlockNumber,
 	}
+	for _, opt := range opts {
+		opt(&args)
+	}
+	args.Privacy = f.applyPrivacyDefaults(args.Privacy)
+
+	if args.Preferences[PreferenceUseMempool] && args.MaxBlockNumber == "" {
+		return nil, errorMempoolWithoutMaxBlock
+	}
+	if args.refundPercent != nil {
+		if err := args.applyRefundPercent(*args.refundPercent); err != nil {
+			return nil, err
+		}
+	}
+	if f.checkMaxBlock && args.MaxBlockNumber != "" {
+		if err := f.validateMaxBlock(args.MaxBlockNumber); err != nil {
+			return nil, err
+		}
+	}
+
+	var replacement replacementKey
+	if f.replacements != nil && args.ReplacementNonce != 0 {
+		var err error
+		if replacement, err = f.replacements.check(args.Transaction, args.ReplacementNonce); err != nil {
+			return nil, err
+		}
+	}
 
-	resp := f.requestRPC(MethodSendPrivateTransaction, args)
+	resp, err := f.requestRPCContext(ctx, MethodSendPrivateTransaction, args)
+	if err != nil {
+		return nil, err
+	}
 	transactionResp := new(SendPrivateTxResponse)
-	if err := json.Unmarshal(resp, transactionResp); err != nil {
+	if err := f.decodeResponse(MethodSendPrivateTransaction, resp, transactionResp); err != nil {
 		return nil, err
 	}
 
+	if f.replacements != nil && args.ReplacementNonce != 0 && transactionResp.Err() == nil {
+		f.replacements.record(replacement, args.ReplacementNonce)
+	}
+
 	return transactionResp, nil
 }
 
+// GetUserStats returns the searcher stats of the signing key as of
+// blockNumber. A blockNumber of 0 uses the next block, which requires NodeRpc.
 func (f *FlashbotLaunch) GetUserStats(blockNumber uint64) (*UserStatsResponse, error) {
-	resp := f.requestRPC(MethodGetUserStats, blockNumber)
+	blockNumber, err := f.resolveBlock(blockNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := f.requestRPC(MethodGetUserStats, blockNumber)
+	if err != nil {
+		return nil, err
+	}
 	userStatusResp := new(UserStatsResponse)
-	if err := json.Unmarshal(resp, userStatusResp); err != nil {
+	if err := f.decodeResponse(MethodGetUserStats, resp, userStatusResp); err != nil {
 		return nil, err
 	}
 
 	return userStatusResp, nil
 }
 
-func (f *FlashbotLaunch) requestRPC(Method string, params ...interface{}) []byte {
+func (f *FlashbotLaunch) requestRPC(Method string, params ...interface{}) ([]byte, error) {
+	return f.requestRPCContext(context.Background(), Method, params...)
+}
+
+// requestRPCContext performs the relay request, giving up when ctx is done.
+func (f *FlashbotLaunch) requestRPCContext(ctx context.Context, Method string, params ...interface{}) ([]byte, error) {
+	return f.requestRPCAs(ctx, f.requestSigner(), Method, params...)
+}
+
+// requestRPCAs performs the relay request signed by signer.
+func (f *FlashbotLaunch) requestRPCAs(ctx context.Context, signer Signer, Method string, params ...interface{}) ([]byte, error) {
+	res, _, err := f.requestRPCFrom(ctx, signer, Method, params...)
+	return res, err
+}
+
+// requestRPCFrom performs the relay request signed by signer and also
+// returns the relay that served it, see doRequest.
+func (f *FlashbotLaunch) requestRPCFrom(ctx context.Context, signer Signer, Method string, params ...interface{}) ([]byte, string, error) {
+	requestParams, err := buildParams(Method, params)
+	if err != nil {
+		return nil, "", err
+	}
+
 	requestArgs := metaRequestParams{
 		JsonRPC: "2.0",
 		Id:      1,
 		Method:  Method,
-		Params:  append(params, params...),
+		Params:  requestParams,
+	}
+
+	return f.doRequest(ctx, signer, requestArgs)
+}
+
+// doRequest sends the signed JSON-RPC request and returns the response body
+// together with the relay that served it: the primary or a fallback relay.
+// On failure relay is the last one tried, or empty when none was.
+func (f *FlashbotLaunch) doRequest(ctx context.Context, signer Signer, requestArgs metaRequestParams) (res []byte, relay string, err error) {
+	if f.limiter != nil {
+		if err := f.limiter.wait(ctx); err != nil {
+			return nil, "", &CanceledError{Method: requestArgs.Method, Err: err}
+		}
 	}
 
-	client := &http.Client{Timeout: 20 * time.Second}
 	payload, err := json.Marshal(requestArgs)
 	if err != nil {
-		log.Fatal(err)
+		return nil, "", err
+	}
+	if f.logRequests {
+		f.logRequest(requestArgs)
 	}
 
-	req, err := http.NewRequest("POST", f.Rpc, bytes.NewBuffer(payload))
+	signature, err := signPayload(payload, signer, f.scheme)
 	if err != nil {
-		log.Fatal(err)
+		return nil, "", err
 	}
 
-	headerReady, _ := crypto.Sign(
-		accounts.TextHash([]byte(hexutil.Encode(crypto.Keccak256(payload)))),
-		f.PrivateKey,
-	)
+	// wrote is set once any relay has received the full request, to tell
+	// whether a cancelled submission may still have reached one.
+	var wrote int32
+	ctx = httptrace.WithClientTrace(ctx, &httptrace.ClientTrace{
+		WroteRequest: func(info httptrace.WroteRequestInfo) {
+			if info.Err == nil {
+				atomic.StoreInt32(&wrote, 1)
+			}
+		},
+	})
+
+	// The signature covers the payload only, so it is valid for every relay.
+	relays := append([]string{f.Rpc}, f.fallbackRelays...)
+	for i, relay := range relays {
+		res, failure, err := f.sendRequest(ctx, relay, payload, signature, signer, requestArgs)
+		if err != nil && ctx.Err() != nil {
+			return nil, relay, &CanceledError{Method: requestArgs.Method, Sent: atomic.LoadInt32(&wrote) == 1, Err: err}
+		}
+		if failure == nil || i == len(relays)-1 {
+			return res, relay, err
+		}
+		f.logf("flashbot: %s via %s failed, trying %s: %v", requestArgs.Method, relay, relays[i+1], failure)
+	}
 
-	signature := flashbotHeader(headerReady, f.PrivateKey)
+	panic("unreachable")
+}
 
-	req.Header.Add("content-type", "application/json")
-	req.Header.Add("Accept", "application/json")
+// sendRequest posts the signed payload to relay. failure is set when the
+// relay failed in a way another relay might not: no response, a truncated
+// one, rate limiting or a server error. A server error still returns its
+// body, which usually holds the relay's JSON-RPC error.
+func (f *FlashbotLaunch) sendRequest(ctx context.Context, relay string, payload []byte, signature string, signer Signer, requestArgs metaRequestParams) (res []byte, failure error, err error) {
+	req, err := http.NewRequestWithContext(ctx, "POST", relay, bytes.NewBuffer(payload))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req.Header.Add("content-type", headerOrDefault(f.contentType))
+	req.Header.Add("Accept", headerOrDefault(f.accept))
 	req.Header.Add("X-Flashbots-Signature", signature)
+	if f.basicAuth != nil {
+		req.SetBasicAuth(f.basicAuth.user, f.basicAuth.pass)
+	}
 
-	resp, err := client.Do(req)
+	start := time.Now()
+	resp, err := f.httpClient().Do(req)
 	if err != nil {
-		log.Fatal(err)
+		return nil, err, err
+	}
+	defer resp.Body.Close()
+
+	res, err = f.readBody(resp.Body)
+	if errors.Is(err, io.ErrUnexpectedEOF) {
+		err = fmt.Errorf("%w: %s after %d bytes", ErrTruncatedResponse, requestArgs.Method, len(res))
+		return nil, err, err
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if f.requestHook != nil {
+		f.requestHook(RequestInfo{
+			Method:   requestArgs.Method,
+			Relay:    relay,
+			Signer:   signer.Address(),
+			Bundle:   bundleUUID(requestArgs.Params),
+			Duration: time.Since(start),
+			Response: ResponseMeta{
+				StatusCode: resp.StatusCode,
+				Header:     resp.Header,
+			},
+		})
+	}
+
+	if resp.StatusCode == http.StatusTooManyRequests {
+		retryAfter, _ := parseRetryAfter(resp.Header.Get("Retry-After"), time.Now())
+		err = &RateLimitedError{Method: requestArgs.Method, RetryAfter: retryAfter}
+		return nil, err, err
+	}
+	if resp.StatusCode >= http.StatusInternalServerError {
+		return res, fmt.Errorf("relay answered %s", resp.Status), nil
+	}
+
+	return res, nil, nil
+}
+
+// readBody reads a response body of at most the configured maximum size.
+func (f *FlashbotLaunch) readBody(body io.Reader) ([]byte, error) {
+	limit := f.maxResponseSize
+	if limit <= 0 {
+		limit = defaultMaxResponseSize
+	}
+
+	res, err := ioutil.ReadAll(io.LimitReader(body, limit+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(res)) > limit {
+		return nil, fmt.Errorf("%w: more than %d bytes", ErrResponseTooLarge, limit)
+	}
+
+	return res, nil
+}
+
+// headerOrDefault returns value, or application/json when it is unset.
+func headerOrDefault(value string) string {
+	if value == "" {
+		return "application/json"
 	}
+	return value
+}
 
-	res, _ := ioutil.ReadAll(resp.Body)
-	return res
+// defaultTransport returns http.DefaultTransport with the connection pool
+// defaults applied.
+func defaultTransport() *http.Transport {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.MaxIdleConns = defaultMaxIdleConns
+	transport.MaxIdleConnsPerHost = defaultMaxIdleConnsPerHost
+	transport.IdleConnTimeout = defaultIdleConnTimeout
+	return transport
 }
 
-func flashbotHeader(signature []byte, privateKey *ecdsa.PrivateKey) string {
-	return crypto.PubkeyToAddress(privateKey.PublicKey).Hex() + ":" + hexutil.Encode(signature)
+// httpClient returns the client used for relay and node requests.
+func (f *FlashbotLaunch) httpClient() *http.Client {
+	if f.client != nil {
+		return f.client
+	}
+	return defaultClient
 }
 
 func HexToECDSA(privateKey string) *ecdsa.PrivateKey {
-	key, err := crypto.HexToECDSA(privateKey)
+	key, err := ParsePrivateKey(privateKey)
 	if err != nil {
 		log.Fatal(err)
 	}
 	return key
 }
 
+// ParsePrivateKey parses a hex encoded secp256k1 key, with or without a 0x
+// prefix.
+func ParsePrivateKey(privateKey string) (*ecdsa.PrivateKey, error) {
+	key, err := crypto.HexToECDSA(strings.TrimPrefix(strings.TrimSpace(privateKey), "0x"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid private key, want 64 hex characters: %w", err)
+	}
+	return key, nil
+}
+
+// ValidatePrivateKey reports whether privateKey is a valid hex encoded
+// secp256k1 key, as HexToECDSA expects, without exiting on failure.
+func ValidatePrivateKey(privateKey string) error {
+	_, err := ParsePrivateKey(privateKey)
+	return err
+}
+
 func HextoBlockNumber(blockNumber uint64) string {
 	return hexutil.EncodeUint64(blockNumber)
 }
@@ -302,6 +943,32 @@ func RelayDefaultRPC(netType string) (string, error) {
 		return "https://relay-goerli.flashbots.net", nil
 
 	default:
-		return "", fmt.Errorf("The netType is wrong!:", netType)
+		return "", fmt.Errorf("The netType is wrong!: %s", netType)
+	}
+}
+
+func NetworkChainID(netType string) (*big.Int, error) {
+	switch netType {
+	case "mainnet":
+		return big.NewInt(1), nil
+	case "goerli":
+		return big.NewInt(5), nil
+
+	default:
+		return nil, fmt.Errorf("unknown network %q", netType)
+	}
+}
+
+// NetworkBeaconGenesis returns the beacon chain genesis time of a network,
+// the start of slot 0.
+func NetworkBeaconGenesis(netType string) (time.Time, error) {
+	switch netType {
+	case "mainnet":
+		return time.Unix(1606824023, 0), nil
+	case "goerli":
+		return time.Unix(1616508000, 0), nil
+
+	default:
+		return time.Time{}, fmt.Errorf("unknown network %q", netType)
 	}
 }
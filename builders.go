@@ -0,0 +1,92 @@
+package flashbot
+
+import (
+	"crypto/ecdsa"
+	"fmt"
+	"sync"
+)
+
+var (
+	buildersMu sync.RWMutex
+	builders   = map[string]string{
+		"flashbots":   "https://relay.flashbots.net",
+		"builder0x69": "https://builder0x69.io",
+		"rsync":       "https://rsync-builder.xyz",
+		"beaverbuild": "https://rpc.beaverbuild.org",
+		"titan":       "https://rpc.titanbuilder.xyz",
+	}
+
+	// mevShareBuilders are the builder names MEV-Share accepts in the
+	// builders preference, which differ from the registry above.
+	mevShareBuildersMu sync.RWMutex
+	mevShareBuilders   = map[string]bool{
+		"flashbots":       true,
+		"f1b.io":          true,
+		"rsync":           true,
+		"beaverbuild.org": true,
+		"builder0x69":     true,
+		"Titan":           true,
+		"EigenPhi":        true,
+		"boba-builder":    true,
+		"Gambit Labs":     true,
+		"payload":         true,
+		"Loki":            true,
+		"BuildAI":         true,
+		"JetBuilder":      true,
+		"tbuilder":        true,
+		"penguinbuild":    true,
+		"bobthebuilder":   true,
+		"BTCS":            true,
+		"bloXroute":       true,
+	}
+)
+
+// RegisterMevShareBuilder adds a builder name to those MEV-Share is known
+// to accept, for builders joining after this package was released.
+func RegisterMevShareBuilder(name string) {
+	mevShareBuildersMu.Lock()
+	defer mevShareBuildersMu.Unlock()
+
+	mevShareBuilders[name] = true
+}
+
+func knownMevShareBuilder(name string) bool {
+	mevShareBuildersMu.RLock()
+	defer mevShareBuildersMu.RUnlock()
+
+	return mevShareBuilders[name]
+}
+
+// RegisterBuilder adds or replaces the direct RPC endpoint of a builder
+// for NewForBuilder.
+func RegisterBuilder(name, url string) {
+	buildersMu.Lock()
+	defer buildersMu.Unlock()
+
+	builders[name] = url
+}
+
+// BuilderRPC returns the direct RPC endpoint registered for a builder.
+func BuilderRPC(name string) (string, bool) {
+	buildersMu.RLock()
+	defer buildersMu.RUnlock()
+
+	url, ok := builders[name]
+	return url, ok
+}
+
+// NewForBuilder returns a client submitting straight to the named builder's
+// RPC endpoint instead of a relay, signing with privateKey. Builders build
+// mainnet blocks, so transactions are signed for mainnet unless
+// WithChainID says otherwise.
+func NewForBuilder(name string, privateKey *ecdsa.PrivateKey, opts ...Option) (*FlashbotLaunch, error) {
+	rpc, ok := BuilderRPC(name)
+	if !ok {
+		return nil, fmt.Errorf("unknown builder %q", name)
+	}
+
+	f := newClient(rpc, privateKey, opts)
+	f.setNetwork("mainnet")
+
+	return f, nil
+}
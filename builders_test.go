@@ -0,0 +1,50 @@
+package flashbot
+
+import (
+	"bytes"
+	"log"
+	"math/big"
+	"strings"
+	"testing"
+)
+
+func TestNewForBuilder(t *testing.T) {
+	key := testKey(t)
+
+	f, err := NewForBuilder("titan", key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if f.Rpc != "https://rpc.titanbuilder.xyz" {
+		t.Errorf("Rpc = %s", f.Rpc)
+	}
+	if f.chainID == nil || f.chainID.Cmp(big.NewInt(1)) != 0 {
+		t.Errorf("chain id = %v, want 1", f.chainID)
+	}
+
+	if f, _ := NewForBuilder("titan", key, WithChainID(big.NewInt(5))); f.chainID.Cmp(big.NewInt(5)) != 0 {
+		t.Errorf("chain id = %v, want WithChainID's 5", f.chainID)
+	}
+
+	if _, err := NewForBuilder("nobody", key); err == nil {
+		t.Error("unknown builder accepted")
+	}
+}
+
+func TestMevShareBuilderNames(t *testing.T) {
+	relay := newTestRelay(t, `{"jsonrpc":"2.0","id":1,"result":"0x01"}`)
+	var logs bytes.Buffer
+	f := newTestClient(t, relay.URL, WithLogger(log.New(&logs, "", 0)))
+
+	f.SendPrivateTransaction(signedTestTx(t, 0), "0x1036649", WithOnlyBuilders("flashbots", "Titan", "titan"))
+	f.SendBundle([]string{signedTestTx(t, 0)}, 17000000, WithBundlePreferences(BundlePreferences{Builders: []string{"beaverbuild.org", "beaverbuild"}}))
+
+	got := strings.Split(strings.TrimSpace(logs.String()), "\n")
+	want := []string{
+		`flashbot: builder "titan" is not a known MEV-Share builder name`,
+		`flashbot: builder "beaverbuild" is not a known MEV-Share builder name`,
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("logged\n%s\nwant\n%s", logs.String(), strings.Join(want, "\n"))
+	}
+}
@@ -0,0 +1,369 @@
+package flashbot
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// ErrBundleHashMismatch is returned by SendBundleWithHash when the relay
+// reports a different bundle hash than the one computed locally.
+var ErrBundleHashMismatch = errors.New("bundle hash mismatch")
+
+// ComputeBundleHash returns the Flashbots bundle hash of the raw signed
+// transactions: keccak256 of their concatenated transaction hashes.
+func ComputeBundleHash(transactions []string) (string, error) {
+	if len(transactions) < 1 {
+		return "", errorTransaction
+	}
+
+	hashes := make([]byte, 0, 32*len(transactions))
+	for i, tx := range transactions {
+		raw, err := hexutil.Decode(tx)
+		if err != nil {
+			return "", fmt.Errorf("transaction %d: %w", i, err)
+		}
+		hashes = append(hashes, crypto.Keccak256(raw)...)
+	}
+
+	return hexutil.Encode(crypto.Keccak256(hashes)), nil
+}
+
+// TxHashes returns the transaction hash of every raw signed transaction.
+func TxHashes(transactions []string) ([]string, error) {
+	hashes := make([]string, len(transactions))
+	for i, tx := range transactions {
+		raw, err := hexutil.Decode(tx)
+		if err != nil {
+			return nil, fmt.Errorf("transaction %d: %w", i, err)
+		}
+		hashes[i] = hexutil.Encode(crypto.Keccak256(raw))
+	}
+
+	return hashes, nil
+}
+
+// SendBundleWithHash sends the bundle like SendBundle and also returns the
+// locally computed bundle hash. If the relay answers with a different hash
+// the response is still returned, together with ErrBundleHashMismatch.
+func (f *FlashbotLaunch) SendBundleWithHash(transactions []string, blockNumber uint64, opts ...BundleOption) (*SendBundleResponse, string, error) {
+	localHash, err := ComputeBundleHash(transactions)
+	if err != nil {
+		return nil, "", err
+	}
+
+	resp, err := f.SendBundle(transactions, blockNumber, opts...)
+	if err != nil {
+		return nil, localHash, err
+	}
+
+	if resp.Result == nil {
+		return resp, localHash, errors.New("relay returned no bundle hash")
+	}
+	if !strings.EqualFold(resp.Result.BundleHash, localHash) {
+		return resp, localHash, fmt.Errorf("%w: relay %s, local %s", ErrBundleHashMismatch, resp.Result.BundleHash, localHash)
+	}
+
+	return resp, localHash, nil
+}
+
+// RelativeBlock returns the absolute block number offset blocks after the
+// latest block known to NodeRpc, so an offset of 1 is the next block.
+func (f *FlashbotLaunch) RelativeBlock(offset uint64) (uint64, error) {
+	if offset == 0 {
+		return 0, errors.New("block offset must be greater than zero")
+	}
+
+	latest, err := f.LatestBlockNumber()
+	if err != nil {
+		return 0, err
+	}
+
+	return latest + offset, nil
+}
+
+// SendBundleRelative sends the bundle targeting offset blocks after the
+// latest block, see RelativeBlock.
+func (f *FlashbotLaunch) SendBundleRelative(transactions []string, offset uint64, opts ...BundleOption) (*SendBundleResponse, error) {
+	blockNumber, err := f.RelativeBlock(offset)
+	if err != nil {
+		return nil, err
+	}
+
+	return f.SendBundle(transactions, blockNumber, opts...)
+}
+
+// ValidateBundleNonces decodes the raw signed transactions and checks that
+// the nonces of every sender strictly increase through the bundle, which
+// catches a sender reusing a nonce.
+func ValidateBundleNonces(transactions []string) error {
+	txs, err := DecodeBundle(transactions)
+	if err != nil {
+		return err
+	}
+
+	last := make(map[common.Address]uint64)
+	for i, tx := range txs {
+		from, err := types.Sender(types.LatestSignerForChainID(tx.ChainId()), tx)
+		if err != nil {
+			return fmt.Errorf("transaction %d: %w", i, err)
+		}
+
+		if prev, ok := last[from]; ok && tx.Nonce() <= prev {
+			return fmt.Errorf("transaction %d: nonce %d of %s does not follow its previous nonce %d", i, tx.Nonce(), from.Hex(), prev)
+		}
+		last[from] = tx.Nonce()
+	}
+
+	return nil
+}
+
+// DecodeBundle decodes the raw signed transactions of a bundle, failing
+// with the index of the first one that does not decode.
+func DecodeBundle(transactions []string) ([]*types.Transaction, error) {
+	txs := make([]*types.Transaction, len(transactions))
+	for i, raw := range transactions {
+		tx, err := decodeTransaction(raw)
+		if err != nil {
+			return nil, fmt.Errorf("transaction %d: %w", i, err)
+		}
+		txs[i] = tx
+	}
+
+	return txs, nil
+}
+
+// ValidateAtomicBundle checks a bundle where any revert means a loss, such
+// as a flashloan arbitrage, before it is sent with opts. The relay already
+// drops the whole bundle when a transaction reverts, unless the options
+// allow that transaction to revert or be dropped, so such options are
+// rejected. The transactions must also decode and form a valid nonce
+// sequence, see ValidateBundleNonces.
+func ValidateAtomicBundle(transactions []string, opts ...BundleOption) error {
+	if len(transactions) < 1 {
+		return errorTransaction
+	}
+
+	var args SendBundleParams
+	for _, opt := range opts {
+		opt(&args)
+	}
+	if len(args.RevertingTxHashes) > 0 {
+		return fmt.Errorf("atomic bundle allows %d transactions to revert", len(args.RevertingTxHashes))
+	}
+	if len(args.DroppingTxHashes) > 0 {
+		return fmt.Errorf("atomic bundle allows %d transactions to be dropped", len(args.DroppingTxHashes))
+	}
+
+	return ValidateBundleNonces(transactions)
+}
+
+func decodeTransaction(raw string) (*types.Transaction, error) {
+	data, err := hexutil.Decode(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	tx := new(types.Transaction)
+	if err := tx.UnmarshalBinary(data); err != nil {
+		return nil, err
+	}
+
+	return tx, nil
+}
+
+// validateTxHashes checks that every entry of the named field is a 0x
+// prefixed 32 byte hash.
+func validateTxHashes(field string, hashes []string) error {
+	for i, hash := range hashes {
+		raw, err := hexutil.Decode(hash)
+		if err != nil || len(raw) != common.HashLength {
+			return fmt.Errorf("%s[%d]: invalid tx hash %q", field, i, hash)
+		}
+	}
+
+	return nil
+}
+
+// validateTimestampWindow checks the minTimestamp and maxTimestamp of a
+// bundle, where 0 means unset: neither may be negative, the window must not
+// be empty and it must not have closed already.
+func validateTimestampWindow(minTimestamp, maxTimestamp int64) error {
+	if minTimestamp < 0 || maxTimestamp < 0 {
+		return fmt.Errorf("negative timestamp window [%d, %d]", minTimestamp, maxTimestamp)
+	}
+	if maxTimestamp == 0 {
+		return nil
+	}
+	if minTimestamp > maxTimestamp {
+		return fmt.Errorf("minTimestamp %d is after maxTimestamp %d", minTimestamp, maxTimestamp)
+	}
+	if now := time.Now().Unix(); maxTimestamp < now {
+		return fmt.Errorf("maxTimestamp %d is in the past, now is %d", maxTimestamp, now)
+	}
+
+	return nil
+}
+
+// BuildSignedBundle signs the unsigned transactions with the client's
+// account for its chain ID and returns them hex encoded, ready for
+// SendBundle. The nonces must strictly increase in bundle order, and typed
+// transactions must not name a different chain.
+func (f *FlashbotLaunch) BuildSignedBundle(txs []*types.Transaction) ([]string, error) {
+	if len(txs) < 1 {
+		return nil, errorTransaction
+	}
+	if f.chainID == nil {
+		return nil, errors.New("chain id is unknown, set it with WithChainID")
+	}
+
+	signer, err := f.accountSigner()
+	if err != nil {
+		return nil, err
+	}
+	txSigner := types.LatestSignerForChainID(f.chainID)
+
+	signed := make([]string, len(txs))
+	for i, tx := range txs {
+		if i > 0 && tx.Nonce() <= txs[i-1].Nonce() {
+			return nil, fmt.Errorf("transaction %d: nonce %d does not follow previous nonce %d", i, tx.Nonce(), txs[i-1].Nonce())
+		}
+
+		raw, err := f.signTransaction(signer, txSigner, tx)
+		if err != nil {
+			return nil, fmt.Errorf("transaction %d: %w", i, err)
+		}
+		signed[i] = raw
+	}
+
+	return signed, nil
+}
+
+// signTransaction signs tx for the client's chain and returns it hex encoded.
+func (f *FlashbotLaunch) signTransaction(signer Signer, txSigner types.Signer, tx *types.Transaction) (string, error) {
+	if tx.Type() != types.LegacyTxType && tx.ChainId().Cmp(f.chainID) != 0 {
+		return "", fmt.Errorf("chain id %s does not match %s", tx.ChainId(), f.chainID)
+	}
+
+	hash := txSigner.Hash(tx)
+	signature, err := signer.Sign(hash[:])
+	if err != nil {
+		return "", err
+	}
+	tx, err = tx.WithSignature(txSigner, signature)
+	if err != nil {
+		return "", err
+	}
+
+	raw, err := tx.MarshalBinary()
+	if err != nil {
+		return "", err
+	}
+	return hexutil.Encode(raw), nil
+}
+
+// BundleBuilder assembles a bundle from transactions signed elsewhere, such
+// as a user's transaction taken from the mempool, and unsigned transactions
+// signed with the client's account. Build keeps the order in which entries
+// were added.
+type BundleBuilder struct {
+	f       *FlashbotLaunch
+	entries []bundleEntry
+}
+
+// bundleEntry holds either a raw signed transaction or one to sign.
+type bundleEntry struct {
+	raw string
+	tx  *types.Transaction
+}
+
+// NewBundleBuilder returns an empty BundleBuilder signing with the client.
+func (f *FlashbotLaunch) NewBundleBuilder() *BundleBuilder {
+	return &BundleBuilder{f: f}
+}
+
+// AddSigned appends a raw signed transaction, which is included unchanged.
+func (b *BundleBuilder) AddSigned(raw string) *BundleBuilder {
+	b.entries = append(b.entries, bundleEntry{raw: raw})
+	return b
+}
+
+// AddUnsigned appends a transaction to be signed by the client's account.
+func (b *BundleBuilder) AddUnsigned(tx *types.Transaction) *BundleBuilder {
+	b.entries = append(b.entries, bundleEntry{tx: tx})
+	return b
+}
+
+// Build signs the unsigned entries and returns the bundle, ready for
+// SendBundle. Signed entries must decode, and the nonces of the unsigned
+// entries must strictly increase, as in BuildSignedBundle.
+func (b *BundleBuilder) Build() ([]string, error) {
+	if len(b.entries) < 1 {
+		return nil, errorTransaction
+	}
+
+	var signer Signer
+	var txSigner types.Signer
+	var prev *types.Transaction
+
+	bundle := make([]string, len(b.entries))
+	for i, entry := range b.entries {
+		if entry.tx == nil {
+			if _, err := decodeTransaction(entry.raw); err != nil {
+				return nil, fmt.Errorf("transaction %d: %w", i, err)
+			}
+			bundle[i] = entry.raw
+			continue
+		}
+
+		if txSigner == nil {
+			if b.f.chainID == nil {
+				return nil, errors.New("chain id is unknown, set it with WithChainID")
+			}
+			var err error
+			if signer, err = b.f.accountSigner(); err != nil {
+				return nil, err
+			}
+			txSigner = types.LatestSignerForChainID(b.f.chainID)
+		}
+		if prev != nil && entry.tx.Nonce() <= prev.Nonce() {
+			return nil, fmt.Errorf("transaction %d: nonce %d does not follow previous nonce %d", i, entry.tx.Nonce(), prev.Nonce())
+		}
+		prev = entry.tx
+
+		raw, err := b.f.signTransaction(signer, txSigner, entry.tx)
+		if err != nil {
+			return nil, fmt.Errorf("transaction %d: %w", i, err)
+		}
+		bundle[i] = raw
+	}
+
+	return bundle, nil
+}
+
+// newUUID returns a random version 4 UUID.
+func newUUID() string {
+	var b [16]byte
+	rand.Read(b[:])
+	b[6] = b[6]&0x0f | 0x40
+	b[8] = b[8]&0x3f | 0x80
+
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}
+
+// bundleUUID returns the client-side bundle UUID of eth_sendBundle params.
+func bundleUUID(requestParams interface{}) string {
+	if params, ok := requestParams.([]interface{}); ok && len(params) > 0 {
+		if args, ok := params[0].(SendBundleParams); ok {
+			return args.uuid
+		}
+	}
+	return ""
+}
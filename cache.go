@@ -0,0 +1,93 @@
+package flashbot
+
+import (
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// CacheStats reports the use of the CallBundle cache, see
+// WithCallBundleCache.
+type CacheStats struct {
+	Hits    uint64
+	Misses  uint64
+	Entries int
+}
+
+type cachedCall struct {
+	resp    *CallBundleResponse
+	expires time.Time
+}
+
+// callCache keeps successful eth_callBundle responses keyed by a hash of the
+// complete request params.
+type callCache struct {
+	ttl time.Duration
+
+	mu      sync.Mutex
+	entries map[common.Hash]cachedCall
+	hits    uint64
+	misses  uint64
+}
+
+func newCallCache(ttl time.Duration) *callCache {
+	return &callCache{ttl: ttl, entries: make(map[common.Hash]cachedCall)}
+}
+
+func callCacheKey(args CallBundleParams) (common.Hash, error) {
+	data, err := json.Marshal(args)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return crypto.Keccak256Hash(data), nil
+}
+
+func (c *callCache) get(key common.Hash) (*CallBundleResponse, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[key]
+	if ok && time.Now().After(entry.expires) {
+		delete(c.entries, key)
+		ok = false
+	}
+	if !ok {
+		c.misses++
+		return nil, false
+	}
+
+	c.hits++
+	return entry.resp, true
+}
+
+func (c *callCache) put(key common.Hash, resp *CallBundleResponse) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := time.Now()
+	for k, entry := range c.entries {
+		if now.After(entry.expires) {
+			delete(c.entries, k)
+		}
+	}
+	c.entries[key] = cachedCall{resp: resp, expires: now.Add(c.ttl)}
+}
+
+func (c *callCache) stats() CacheStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return CacheStats{Hits: c.hits, Misses: c.misses, Entries: len(c.entries)}
+}
+
+// CallBundleCacheStats returns the hits, misses and live entries of the
+// CallBundle cache. It is zero without WithCallBundleCache.
+func (f *FlashbotLaunch) CallBundleCacheStats() CacheStats {
+	if f.callCache == nil {
+		return CacheStats{}
+	}
+	return f.callCache.stats()
+}
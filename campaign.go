@@ -0,0 +1,299 @@
+package flashbot
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// ErrBudgetExhausted is returned by SubmitCampaign when the campaign's
+// Budget ran out before every target block was submitted.
+var ErrBudgetExhausted = errors.New("submission budget exhausted")
+
+// maxCampaignBlocks caps the target blocks of one campaign, about nine
+// days of mainnet blocks, so its results can be buffered up front.
+const maxCampaignBlocks = 1 << 16
+
+// Campaign describes one bundle submitted to a range of target blocks.
+type Campaign struct {
+	Transactions []string
+	// BlockTransactions, when set, is called before each target block for
+	// freshly signed transactions, e.g. paying the builder more every
+	// block. It replaces Transactions.
+	BlockTransactions func(block uint64) ([]string, error)
+	// FromBlock and ToBlock are the first and last target block, inclusive,
+	// at most maxCampaignBlocks apart.
+	FromBlock uint64
+	ToBlock   uint64
+	// Retries is how many more times a block submission failing with a
+	// retryable error, see IsRetryable, is tried. Relay rejections and
+	// local validation errors are not retried. After a 429 the retry waits
+	// for the relay's Retry-After delay, capped by the context deadline.
+	Retries int
+	Budget  Budget
+	Options []BundleOption
+	// Dedup, when set, skips blocks it already saw this exact bundle
+	// accepted for. Share one Deduplicator across the SubmitCampaign calls
+	// of a resubmission loop.
+	Dedup *Deduplicator
+	// Force resubmits even bundles Dedup has already seen.
+	Force bool
+}
+
+// Budget caps the relay usage of a whole campaign, across all blocks and
+// retries. Zero fields are not limited. MaxDuration also cuts short a
+// request still in flight when it runs out.
+type Budget struct {
+	MaxRequests int
+	MaxDuration time.Duration
+}
+
+// BlockResult is the outcome of a campaign's submission for one block.
+type BlockResult struct {
+	BlockNumber uint64
+	Response    *SendBundleResponse
+	Err         error
+	// Skipped is set when Dedup had already seen the bundle for this block;
+	// Response is then the earlier response.
+	Skipped bool
+}
+
+// Deduplicator remembers the bundles accepted for each block, keyed by a
+// hash of the transactions and block number. Re-signed transactions, e.g.
+// after a gas bump, hash differently and are submitted again. The zero
+// value is ready to use.
+type Deduplicator struct {
+	mu   sync.Mutex
+	seen map[common.Hash]*SendBundleResponse
+}
+
+func dedupKey(transactions []string, blockNumber uint64) common.Hash {
+	data := make([]byte, 8, 8+32*len(transactions))
+	binary.BigEndian.PutUint64(data, blockNumber)
+	for _, tx := range transactions {
+		data = append(data, crypto.Keccak256([]byte(tx))...)
+	}
+
+	return crypto.Keccak256Hash(data)
+}
+
+func (d *Deduplicator) lookup(key common.Hash) (*SendBundleResponse, bool) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	resp, ok := d.seen[key]
+	return resp, ok
+}
+
+func (d *Deduplicator) add(key common.Hash, resp *SendBundleResponse) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.seen == nil {
+		d.seen = make(map[common.Hash]*SendBundleResponse)
+	}
+	d.seen[key] = resp
+}
+
+// Reset forgets every bundle seen so far.
+func (d *Deduplicator) Reset() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.seen = nil
+}
+
+type budgetTracker struct {
+	Budget
+	start    time.Time
+	requests int
+}
+
+// spend reserves one request, failing once the budget is used up.
+func (b *budgetTracker) spend() error {
+	if b.MaxRequests > 0 && b.requests >= b.MaxRequests {
+		return fmt.Errorf("%w: %d requests sent", ErrBudgetExhausted, b.requests)
+	}
+	if b.MaxDuration > 0 && time.Since(b.start) >= b.MaxDuration {
+		return fmt.Errorf("%w: %s elapsed", ErrBudgetExhausted, b.MaxDuration)
+	}
+
+	b.requests++
+	return nil
+}
+
+// SubmitCampaign sends the campaign's bundle for every target block in
+// order, retrying failed submissions. It stops early when ctx is done or
+// the budget is exhausted, returning the results gathered so far together
+// with the reason.
+func (f *FlashbotLaunch) SubmitCampaign(ctx context.Context, c Campaign) ([]BlockResult, error) {
+	if err := c.validate(); err != nil {
+		return nil, err
+	}
+
+	results := make([]BlockResult, 0, c.ToBlock-c.FromBlock+1)
+	err := f.runCampaign(ctx, c, func(result BlockResult) {
+		results = append(results, result)
+	})
+
+	return results, err
+}
+
+// CampaignHandle controls a campaign running in the background, see
+// StartCampaign.
+type CampaignHandle struct {
+	cancel  context.CancelFunc
+	results chan BlockResult
+	done    chan struct{}
+	err     error
+}
+
+// StartCampaign runs the campaign like SubmitCampaign but in the
+// background, streaming each block's result as soon as it is known.
+func (f *FlashbotLaunch) StartCampaign(ctx context.Context, c Campaign) (*CampaignHandle, error) {
+	if err := c.validate(); err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	h := &CampaignHandle{
+		cancel:  cancel,
+		results: make(chan BlockResult, c.ToBlock-c.FromBlock+1),
+		done:    make(chan struct{}),
+	}
+
+	go func() {
+		defer cancel()
+		defer close(h.done)
+		defer close(h.results)
+
+		h.err = f.runCampaign(ctx, c, func(result BlockResult) {
+			h.results <- result
+		})
+	}()
+
+	return h, nil
+}
+
+// Results returns the per-block results in block order. The channel is
+// buffered for the whole campaign and closed when it ends.
+func (h *CampaignHandle) Results() <-chan BlockResult {
+	return h.results
+}
+
+// Cancel stops the campaign before its next submission. Submissions
+// already sent to the relay are not withdrawn.
+func (h *CampaignHandle) Cancel() {
+	h.cancel()
+}
+
+// Wait blocks until the campaign ends and returns why it stopped early,
+// or nil when every block was handled.
+func (h *CampaignHandle) Wait() error {
+	<-h.done
+	return h.err
+}
+
+func (c *Campaign) validate() error {
+	if len(c.Transactions) < 1 && c.BlockTransactions == nil {
+		return errorTransaction
+	}
+	if c.FromBlock > c.ToBlock {
+		return fmt.Errorf("FromBlock %d is after ToBlock %d", c.FromBlock, c.ToBlock)
+	}
+	if c.ToBlock-c.FromBlock >= maxCampaignBlocks {
+		return fmt.Errorf("blocks %d to %d exceed the campaign limit of %d blocks", c.FromBlock, c.ToBlock, maxCampaignBlocks)
+	}
+
+	return nil
+}
+
+// runCampaign submits every block of the campaign, passing each result to
+// emit, and returns the reason it stopped early if any.
+func (f *FlashbotLaunch) runCampaign(ctx context.Context, c Campaign, emit func(BlockResult)) error {
+	budget := &budgetTracker{Budget: c.Budget, start: time.Now()}
+	if c.Budget.MaxDuration > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithDeadlineCause(ctx, budget.start.Add(c.Budget.MaxDuration),
+			fmt.Errorf("%w: %s elapsed", ErrBudgetExhausted, c.Budget.MaxDuration))
+		defer cancel()
+	}
+
+	// Stop on ToBlock itself rather than past it, which would wrap around
+	// when ToBlock is math.MaxUint64.
+	for block := c.FromBlock; ; block++ {
+		result, attempted, err := f.submitCampaignBlock(ctx, &c, block, budget)
+		if attempted {
+			emit(result)
+		}
+		if err != nil {
+			return err
+		}
+		if block == c.ToBlock {
+			break
+		}
+	}
+
+	return nil
+}
+
+// submitCampaignBlock submits the campaign's bundle for one block. It
+// returns a non-nil error only when the whole campaign has to stop, and
+// reports whether anything was attempted for the block.
+func (f *FlashbotLaunch) submitCampaignBlock(ctx context.Context, c *Campaign, block uint64, budget *budgetTracker) (BlockResult, bool, error) {
+	result := BlockResult{BlockNumber: block}
+
+	transactions := c.Transactions
+	if c.BlockTransactions != nil {
+		if err := context.Cause(ctx); err != nil {
+			return result, false, err
+		}
+		var err error
+		if transactions, err = c.BlockTransactions(block); err != nil {
+			result.Err = fmt.Errorf("transactions for block %d: %w", block, err)
+			return result, true, nil
+		}
+	}
+
+	var key common.Hash
+	if c.Dedup != nil {
+		key = dedupKey(transactions, block)
+		if resp, ok := c.Dedup.lookup(key); ok && !c.Force {
+			result.Response, result.Skipped = resp, true
+			return result, true, nil
+		}
+	}
+
+	for attempt := 0; attempt <= c.Retries; attempt++ {
+		if err := context.Cause(ctx); err != nil {
+			return result, attempt > 0, err
+		}
+		if err := budget.spend(); err != nil {
+			return result, attempt > 0, err
+		}
+
+		result.Response, result.Err = f.SendBundleContext(ctx, transactions, block, c.Options...)
+		if result.Err == nil {
+			result.Err = result.Response.Err()
+		}
+		if result.Err == nil || !IsRetryable(result.Err) {
+			break
+		}
+		if attempt < c.Retries {
+			if err := waitRetryAfter(ctx, result.Err); err != nil {
+				return result, true, context.Cause(ctx)
+			}
+		}
+	}
+
+	if c.Dedup != nil && result.Err == nil {
+		c.Dedup.add(key, result.Response)
+	}
+	return result, true, nil
+}
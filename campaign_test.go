@@ -0,0 +1,132 @@
+package flashbot
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestCampaignRetries(t *testing.T) {
+	tests := []struct {
+		name     string
+		status   int
+		body     string
+		requests int32
+	}{
+		{
+			name:     "relay rejection",
+			status:   http.StatusOK,
+			body:     `{"jsonrpc":"2.0","id":1,"error":{"code":-32000,"message":"bundle rejected"}}`,
+			requests: 1,
+		},
+		{
+			name:     "rate limited",
+			status:   http.StatusTooManyRequests,
+			requests: 3,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requests int32
+			relay := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				io.Copy(io.Discard, r.Body)
+				atomic.AddInt32(&requests, 1)
+				w.WriteHeader(tt.status)
+				io.WriteString(w, tt.body)
+			}))
+			defer relay.Close()
+			f := newTestClient(t, relay.URL)
+
+			results, err := f.SubmitCampaign(context.Background(), Campaign{
+				Transactions: []string{signedTestTx(t, 0)},
+				FromBlock:    17000000,
+				ToBlock:      17000000,
+				Retries:      2,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(results) != 1 || results[0].Err == nil {
+				t.Fatalf("results = %+v, want one failed block", results)
+			}
+			if n := atomic.LoadInt32(&requests); n != tt.requests {
+				t.Errorf("relay got %d requests, want %d", n, tt.requests)
+			}
+		})
+	}
+}
+
+func TestCampaignMaxDuration(t *testing.T) {
+	relay := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.Copy(io.Discard, r.Body)
+		<-r.Context().Done()
+	}))
+	defer relay.Close()
+	f := newTestClient(t, relay.URL)
+
+	start := time.Now()
+	results, err := f.SubmitCampaign(context.Background(), Campaign{
+		Transactions: []string{signedTestTx(t, 0)},
+		FromBlock:    17000000,
+		ToBlock:      17000001,
+		Budget:       Budget{MaxDuration: 50 * time.Millisecond},
+	})
+	if !errors.Is(err, ErrBudgetExhausted) {
+		t.Fatalf("err = %v, want ErrBudgetExhausted", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("campaign took %s, want it cut short by MaxDuration", elapsed)
+	}
+
+	var canceled *CanceledError
+	if len(results) != 1 || !errors.As(results[0].Err, &canceled) {
+		t.Fatalf("results = %+v, want the in-flight block canceled", results)
+	}
+}
+
+func TestCampaignValidateRange(t *testing.T) {
+	tests := []struct {
+		name      string
+		from, to  uint64
+		wantError bool
+	}{
+		{name: "single block", from: 17000000, to: 17000000},
+		{name: "at the limit", from: 17000000, to: 17000000 + maxCampaignBlocks - 1},
+		{name: "past the limit", from: 17000000, to: 17000000 + maxCampaignBlocks, wantError: true},
+		{name: "whole range", from: 0, to: math.MaxUint64, wantError: true},
+		{name: "reversed", from: 17000001, to: 17000000, wantError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := Campaign{Transactions: []string{"0x00"}, FromBlock: tt.from, ToBlock: tt.to}
+			if err := c.validate(); (err != nil) != tt.wantError {
+				t.Errorf("validate() = %v, want error %t", err, tt.wantError)
+			}
+		})
+	}
+}
+
+func TestCampaignLastBlock(t *testing.T) {
+	relay := newTestRelay(t, `{"jsonrpc":"2.0","id":1,"result":{"bundleHash":"0x01"}}`)
+	f := newTestClient(t, relay.URL)
+
+	results, err := f.SubmitCampaign(context.Background(), Campaign{
+		Transactions: []string{signedTestTx(t, 0)},
+		FromBlock:    math.MaxUint64 - 1,
+		ToBlock:      math.MaxUint64,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 2 || results[1].BlockNumber != math.MaxUint64 {
+		t.Errorf("results = %+v, want blocks up to math.MaxUint64 once each", results)
+	}
+}
@@ -0,0 +1,103 @@
+package flashbot
+
+import (
+	"encoding/json"
+	"errors"
+)
+
+// ##############
+// cancelBundle
+// ##############
+type CancelBundleParams struct {
+	ReplacementUuid string `json:"replacementUuid"`
+}
+
+type CancelBundleResponse struct {
+	ID      uint            `json:"id"`
+	Version string          `json:"jsonrpc"`
+	Result  json.RawMessage `json:"result"`
+	Error   *errorResult    `json:"error"`
+}
+
+// Err returns the error of a failed eth_cancelBundle call, or nil.
+func (r *CancelBundleResponse) Err() error {
+	if r.Error != nil {
+		return r.Error
+	}
+	return nil
+}
+
+// CancelBundle withdraws the bundles sent with replacementUuid uuid, see
+// WithBundleUUID and WithReplacementUUID. A bundle already picked up by a
+// builder for its block may still land.
+func (f *FlashbotLaunch) CancelBundle(uuid string) (*CancelBundleResponse, error) {
+	if uuid == "" {
+		return nil, errors.New("cancel bundle: empty replacement uuid")
+	}
+
+	resp, err := f.requestRPC(MethodCancelBundle, CancelBundleParams{ReplacementUuid: uuid})
+	if err != nil {
+		return nil, err
+	}
+	cancelResp := new(CancelBundleResponse)
+	if err := f.decodeResponse(MethodCancelBundle, resp, cancelResp); err != nil {
+		return nil, err
+	}
+
+	return cancelResp, nil
+}
+
+// BlockHandle is the submission of a bundle for one block by
+// SendBundleBlocks, cancellable on its own.
+type BlockHandle struct {
+	BlockNumber uint64
+	// UUID is the replacementUuid the submission was sent with.
+	UUID     string
+	Response *SendBundleResponse
+	Err      error
+
+	f *FlashbotLaunch
+}
+
+// Cancel withdraws this block's submission with eth_cancelBundle, leaving
+// the other blocks untouched.
+func (h *BlockHandle) Cancel() error {
+	if h.UUID == "" {
+		return errors.New("cancel bundle: submission has no replacement uuid")
+	}
+
+	resp, err := h.f.CancelBundle(h.UUID)
+	if err != nil {
+		return err
+	}
+	return resp.Err()
+}
+
+// SendBundleBlocks sends the bundle for every block in blocks, each with its
+// own replacementUuid even without WithBundleUUID, and returns one handle
+// per block in the same order.
+// A failed block is reported in its handle's Err and does not stop the
+// others. Do not pass WithReplacementUUID, which would give every block the
+// same UUID so that cancelling one cancels all.
+func (f *FlashbotLaunch) SendBundleBlocks(transactions []string, blocks []uint64, opts ...BundleOption) ([]*BlockHandle, error) {
+	if len(transactions) < 1 {
+		return nil, errorTransaction
+	}
+	if len(blocks) < 1 {
+		return nil, errors.New("no target blocks")
+	}
+
+	handles := make([]*BlockHandle, len(blocks))
+	for i, block := range blocks {
+		h := &BlockHandle{BlockNumber: block, f: f}
+		blockOpts := append([]BundleOption{WithReplacementUUID(newUUID())}, opts...)
+		h.Response, h.Err = f.SendBundle(transactions, block, blockOpts...)
+		if h.Err == nil {
+			h.UUID = h.Response.UUID
+			h.Err = h.Response.Err()
+		}
+		handles[i] = h
+	}
+
+	return handles, nil
+}
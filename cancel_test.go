@@ -0,0 +1,58 @@
+package flashbot
+
+import (
+	"strings"
+	"testing"
+)
+
+const bundleResult = `{"jsonrpc":"2.0","id":1,"result":{"bundleHash":"0x01"}}`
+
+func TestBundleUUIDOptIn(t *testing.T) {
+	tx := signedTestTx(t, 0)
+
+	t.Run("default", func(t *testing.T) {
+		relay := newTestRelay(t, bundleResult)
+		var hooked string
+		f := newTestClient(t, relay.URL, WithRequestHook(func(info RequestInfo) { hooked = info.Bundle }))
+
+		resp, err := f.SendBundle([]string{tx}, 17000000)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if resp.UUID == "" || hooked != resp.UUID {
+			t.Errorf("UUID = %q, hook saw %q, want the same local UUID", resp.UUID, hooked)
+		}
+		if got := relay.lastParams(t); strings.Contains(got, "replacementUuid") {
+			t.Errorf("params = %s, want no replacementUuid", got)
+		}
+	})
+
+	t.Run("WithBundleUUID", func(t *testing.T) {
+		relay := newTestRelay(t, bundleResult)
+		f := newTestClient(t, relay.URL, WithBundleUUID())
+
+		resp, err := f.SendBundle([]string{tx}, 17000000)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := relay.lastParams(t); !strings.Contains(got, `"replacementUuid":"`+resp.UUID+`"`) {
+			t.Errorf("params = %s, want replacementUuid %s", got, resp.UUID)
+		}
+	})
+
+	t.Run("SendBundleBlocks", func(t *testing.T) {
+		relay := newTestRelay(t, bundleResult)
+		f := newTestClient(t, relay.URL)
+
+		handles, err := f.SendBundleBlocks([]string{tx}, []uint64{17000000, 17000001})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if handles[0].UUID == "" || handles[0].UUID == handles[1].UUID {
+			t.Fatalf("UUIDs %q and %q, want distinct ones", handles[0].UUID, handles[1].UUID)
+		}
+		if got := relay.lastParams(t); !strings.Contains(got, `"replacementUuid":"`+handles[1].UUID+`"`) {
+			t.Errorf("params = %s, want replacementUuid %s", got, handles[1].UUID)
+		}
+	})
+}
@@ -0,0 +1,70 @@
+package flashbot
+
+import "sync"
+
+// ClientStats counts the bundles a client sent to a relay since it was
+// created.
+type ClientStats struct {
+	Sent     uint64
+	Accepted uint64
+	Errored  uint64
+
+	// ByRelay breaks the counters down by the URL of the relay that served
+	// each submission, a fallback relay when the primary one failed over.
+	ByRelay map[string]RelaySubmissionStats
+}
+
+// RelaySubmissionStats counts the bundles submitted to one relay.
+type RelaySubmissionStats struct {
+	Sent     uint64
+	Accepted uint64
+	Errored  uint64
+}
+
+// submissionCounters tracks ClientStats for concurrent SendBundle calls.
+type submissionCounters struct {
+	mu      sync.Mutex
+	byRelay map[string]*RelaySubmissionStats
+}
+
+// count records one submission to relay. A bundle is accepted when the
+// relay answered with a result and errored otherwise, including transport
+// failures. Bundles rejected by local checks never reach a relay and are
+// not counted.
+func (c *submissionCounters) count(relay string, accepted bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.byRelay == nil {
+		c.byRelay = make(map[string]*RelaySubmissionStats)
+	}
+	stats, ok := c.byRelay[relay]
+	if !ok {
+		stats = new(RelaySubmissionStats)
+		c.byRelay[relay] = stats
+	}
+
+	stats.Sent++
+	if accepted {
+		stats.Accepted++
+	} else {
+		stats.Errored++
+	}
+}
+
+// Stats returns a snapshot of the bundles sent by the client, as accepted
+// or errored by the relay.
+func (f *FlashbotLaunch) Stats() ClientStats {
+	f.submissions.mu.Lock()
+	defer f.submissions.mu.Unlock()
+
+	stats := ClientStats{ByRelay: make(map[string]RelaySubmissionStats, len(f.submissions.byRelay))}
+	for relay, relayStats := range f.submissions.byRelay {
+		stats.Sent += relayStats.Sent
+		stats.Accepted += relayStats.Accepted
+		stats.Errored += relayStats.Errored
+		stats.ByRelay[relay] = *relayStats
+	}
+
+	return stats
+}
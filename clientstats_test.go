@@ -0,0 +1,36 @@
+package flashbot
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestStatsCountServingRelay(t *testing.T) {
+	primary := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.Copy(io.Discard, r.Body)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer primary.Close()
+	fallback := newTestRelay(t, `{"jsonrpc":"2.0","id":1,"result":{"bundleHash":"0x01"}}`)
+
+	f := newTestClient(t, primary.URL, WithFallbackRelays(fallback.URL))
+	if _, err := f.SendBundle([]string{signedTestTx(t, 0)}, 17000000); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := f.SendBundle(nil, 17000000); err == nil {
+		t.Fatal("empty bundle accepted")
+	}
+
+	stats := f.Stats()
+	if got := stats.ByRelay[fallback.URL]; got != (RelaySubmissionStats{Sent: 1, Accepted: 1}) {
+		t.Errorf("fallback stats = %+v, want the served bundle", got)
+	}
+	if _, ok := stats.ByRelay[primary.URL]; ok {
+		t.Errorf("primary stats = %+v, want none for a failed over and a locally rejected bundle", stats.ByRelay[primary.URL])
+	}
+	if stats.Sent != 1 {
+		t.Errorf("Sent = %d, want 1", stats.Sent)
+	}
+}
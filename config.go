@@ -0,0 +1,209 @@
+package flashbot
+
+import (
+	"crypto/ecdsa"
+	"errors"
+	"math/big"
+	"net/http"
+	"os"
+	"time"
+)
+
+// Config is the serializable configuration of a client, for config driven
+// setups. It holds no key material: keys are supplied to NewFromConfig
+// separately.
+//
+// Settings that are code or secrets are left out and have to be passed to
+// NewFromConfig as options again: WithSigner, WithKeyPool,
+// WithRequestHook, WithSubmissionRecorder, WithLogger, WithHTTPClient,
+// WithProxy, WithBasicAuth and WithInsecureSkipVerify.
+type Config struct {
+	// Network names a default relay and chain, see RelayDefaultRPC.
+	Network string `json:"network,omitempty"`
+	// Relay overrides the relay URL of Network. FallbackRelays are tried
+	// after it, see WithFallbackRelays.
+	Relay               string          `json:"relay,omitempty"`
+	FallbackRelays      []string        `json:"fallbackRelays,omitempty"`
+	NodeRpc             string          `json:"nodeRpc,omitempty"`
+	ChainID             *big.Int        `json:"chainId,omitempty"`
+	Timeout             time.Duration   `json:"timeout,omitempty"`
+	ContentType         string          `json:"contentType,omitempty"`
+	Accept              string          `json:"accept,omitempty"`
+	SignatureScheme     SignatureScheme `json:"signatureScheme,omitempty"`
+	TargetBlockCheck    bool            `json:"targetBlockCheck,omitempty"`
+	NonceCheck          bool            `json:"nonceCheck,omitempty"`
+	MaxBlockCheck       bool            `json:"maxBlockCheck,omitempty"`
+	ReplacementTracking bool            `json:"replacementTracking,omitempty"`
+	// SubmissionDeadline is the margin of WithSubmissionDeadline.
+	SubmissionDeadline time.Duration `json:"submissionDeadline,omitempty"`
+	Debug              bool          `json:"debug,omitempty"`
+	RequestLog         bool          `json:"requestLog,omitempty"`
+	// BundleUUID sends generated bundle UUIDs, see WithBundleUUID.
+	BundleUUID bool `json:"bundleUuid,omitempty"`
+	// MaxResponseSize caps response bodies, see WithMaxResponseSize.
+	MaxResponseSize int64 `json:"maxResponseSize,omitempty"`
+	// DefaultPrivacyHints are the MEV-Share hints applied by default, see
+	// WithDefaultPrivacyHints.
+	DefaultPrivacyHints []string `json:"defaultPrivacyHints,omitempty"`
+	// CallBundleCache is the TTL of cached simulations, see
+	// WithCallBundleCache.
+	CallBundleCache time.Duration `json:"callBundleCache,omitempty"`
+	// ConnectionPool sizes the idle connection pool when it differs from
+	// the defaults, see WithConnectionPool.
+	ConnectionPool *ConnectionPool `json:"connectionPool,omitempty"`
+	// RateLimit limits relay requests, see WithRateLimit.
+	RateLimit *RateLimit `json:"rateLimit,omitempty"`
+}
+
+// RateLimit holds the arguments of WithRateLimit.
+type RateLimit struct {
+	RPS   float64 `json:"rps"`
+	Burst int     `json:"burst"`
+}
+
+// ConnectionPool holds the arguments of WithConnectionPool.
+type ConnectionPool struct {
+	MaxIdleConns        int           `json:"maxIdleConns"`
+	MaxIdleConnsPerHost int           `json:"maxIdleConnsPerHost"`
+	IdleConnTimeout     time.Duration `json:"idleConnTimeout"`
+}
+
+// Config returns the client's configuration.
+func (f *FlashbotLaunch) Config() Config {
+	cfg := Config{
+		Network:             f.network,
+		Relay:               f.Rpc,
+		FallbackRelays:      f.fallbackRelays,
+		NodeRpc:             f.NodeRpc,
+		ChainID:             f.chainID,
+		Timeout:             f.httpClient().Timeout,
+		ContentType:         f.contentType,
+		Accept:              f.accept,
+		SignatureScheme:     f.scheme,
+		TargetBlockCheck:    f.checkTargetBlock,
+		NonceCheck:          f.checkNonces,
+		MaxBlockCheck:       f.checkMaxBlock,
+		ReplacementTracking: f.replacements != nil,
+		SubmissionDeadline:  f.submitMargin,
+		Debug:               f.debug,
+		RequestLog:          f.logRequests,
+		BundleUUID:          f.sendBundleUUID,
+		MaxResponseSize:     f.maxResponseSize,
+		DefaultPrivacyHints: f.defaultHints,
+	}
+	if f.callCache != nil {
+		cfg.CallBundleCache = f.callCache.ttl
+	}
+	if f.limiter != nil {
+		cfg.RateLimit = &RateLimit{RPS: f.limiter.rate, Burst: int(f.limiter.burst)}
+	}
+	if transport, ok := f.httpClient().Transport.(*http.Transport); ok {
+		pool := ConnectionPool{
+			MaxIdleConns:        transport.MaxIdleConns,
+			MaxIdleConnsPerHost: transport.MaxIdleConnsPerHost,
+			IdleConnTimeout:     transport.IdleConnTimeout,
+		}
+		if pool != (ConnectionPool{defaultMaxIdleConns, defaultMaxIdleConnsPerHost, defaultIdleConnTimeout}) {
+			cfg.ConnectionPool = &pool
+		}
+	}
+
+	return cfg
+}
+
+// NewFromConfig returns a client configured by cfg, with opts applied on
+// top. It signs with the signer or key pool given in opts, or else with
+// the PRIVATE_KEY environment variable like New.
+func NewFromConfig(cfg Config, opts ...Option) (*FlashbotLaunch, error) {
+	rpc := cfg.Relay
+	if rpc == "" {
+		var err error
+		if rpc, err = RelayDefaultRPC(cfg.Network); err != nil {
+			return nil, err
+		}
+	}
+
+	f := newClient(rpc, nil, append(cfg.options(), opts...))
+	f.network = cfg.Network
+	if f.chainID == nil && cfg.Network != "" {
+		f.chainID, _ = NetworkChainID(cfg.Network)
+	}
+
+	if f.signer == nil && f.keys == nil {
+		key, err := privateKeyFromEnv()
+		if err != nil {
+			return nil, err
+		}
+		f.PrivateKey = key
+	}
+
+	return f, nil
+}
+
+func (cfg Config) options() []Option {
+	opts := []Option{
+		WithNodeRpc(cfg.NodeRpc),
+		WithContentType(cfg.ContentType),
+		WithAccept(cfg.Accept),
+		WithSignatureScheme(cfg.SignatureScheme),
+	}
+	if len(cfg.FallbackRelays) > 0 {
+		opts = append(opts, WithFallbackRelays(cfg.FallbackRelays...))
+	}
+	if cfg.ChainID != nil {
+		opts = append(opts, WithChainID(cfg.ChainID))
+	}
+	if cfg.Timeout > 0 {
+		opts = append(opts, WithTimeout(cfg.Timeout))
+	}
+	if cfg.TargetBlockCheck {
+		opts = append(opts, WithTargetBlockCheck())
+	}
+	if cfg.NonceCheck {
+		opts = append(opts, WithNonceCheck())
+	}
+	if cfg.MaxBlockCheck {
+		opts = append(opts, WithMaxBlockCheck())
+	}
+	if cfg.ReplacementTracking {
+		opts = append(opts, WithReplacementTracking())
+	}
+	if cfg.SubmissionDeadline > 0 {
+		opts = append(opts, WithSubmissionDeadline(cfg.SubmissionDeadline))
+	}
+	if cfg.Debug {
+		opts = append(opts, WithDebug())
+	}
+	if cfg.RequestLog {
+		opts = append(opts, WithRequestLog())
+	}
+	if cfg.BundleUUID {
+		opts = append(opts, WithBundleUUID())
+	}
+	if cfg.MaxResponseSize > 0 {
+		opts = append(opts, WithMaxResponseSize(cfg.MaxResponseSize))
+	}
+	if len(cfg.DefaultPrivacyHints) > 0 {
+		opts = append(opts, WithDefaultPrivacyHints(cfg.DefaultPrivacyHints...))
+	}
+	if cfg.CallBundleCache > 0 {
+		opts = append(opts, WithCallBundleCache(cfg.CallBundleCache))
+	}
+	if pool := cfg.ConnectionPool; pool != nil {
+		opts = append(opts, WithConnectionPool(pool.MaxIdleConns, pool.MaxIdleConnsPerHost, pool.IdleConnTimeout))
+	}
+	if limit := cfg.RateLimit; limit != nil {
+		opts = append(opts, WithRateLimit(limit.RPS, limit.Burst))
+	}
+
+	return opts
+}
+
+func privateKeyFromEnv() (*ecdsa.PrivateKey, error) {
+	privateKey := os.Getenv("PRIVATE_KEY")
+	if privateKey == "" {
+		return nil, errors.New("PRIVATE_KEY is not set")
+	}
+
+	return ParsePrivateKey(privateKey)
+}
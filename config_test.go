@@ -0,0 +1,90 @@
+package flashbot
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+func TestKeyPoolWithoutAccount(t *testing.T) {
+	f, err := NewFromConfig(Config{Network: "mainnet"}, WithKeyPool([]*ecdsa.PrivateKey{testKey(t)}, RoundRobin))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	to := common.HexToAddress("0x000000000000000000000000000000000000dEaD")
+	tx := types.NewTransaction(0, to, big.NewInt(1), 21000, big.NewInt(30e9), nil)
+
+	if _, err := f.BuildSignedBundle([]*types.Transaction{tx}); !errors.Is(err, errorNoAccount) {
+		t.Errorf("BuildSignedBundle err = %v, want errorNoAccount", err)
+	}
+	if _, err := f.NewBundleBuilder().AddUnsigned(tx).Build(); !errors.Is(err, errorNoAccount) {
+		t.Errorf("Build err = %v, want errorNoAccount", err)
+	}
+}
+
+func TestConfigRoundTrip(t *testing.T) {
+	signer := WithSigner(NewKeySigner(testKey(t)))
+	base, err := NewFromConfig(Config{Network: "mainnet"}, signer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	baseData, err := json.Marshal(base.Config())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		opt  Option
+	}{
+		{name: "bundle uuid", opt: WithBundleUUID()},
+		{name: "max response size", opt: WithMaxResponseSize(1 << 20)},
+		{name: "default privacy hints", opt: WithDefaultPrivacyHints("hash", "logs")},
+		{name: "call bundle cache", opt: WithCallBundleCache(time.Second)},
+		{name: "connection pool", opt: WithConnectionPool(8, 4, time.Minute)},
+		{name: "max block check", opt: WithMaxBlockCheck()},
+		{name: "rate limit", opt: WithRateLimit(2.5, 3)},
+		{name: "submission deadline", opt: WithSubmissionDeadline(2 * time.Second)},
+		{name: "fallback relays", opt: WithFallbackRelays("https://rpc.titanbuilder.xyz", "https://rpc.beaverbuild.org")},
+		{name: "replacement tracking", opt: WithReplacementTracking()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := NewFromConfig(Config{Network: "mainnet"}, signer, tt.opt)
+			if err != nil {
+				t.Fatal(err)
+			}
+			want := f.Config()
+
+			data, err := json.Marshal(want)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if bytes.Equal(data, baseData) {
+				t.Fatalf("config %s does not carry the option", data)
+			}
+			var cfg Config
+			if err := json.Unmarshal(data, &cfg); err != nil {
+				t.Fatal(err)
+			}
+
+			reloaded, err := NewFromConfig(cfg, signer)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := reloaded.Config(); !reflect.DeepEqual(got, want) {
+				t.Errorf("reloaded config = %+v, want %+v", got, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,156 @@
+package flashbot
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// logf writes to the configured logger, or the standard logger if none is set.
+func (f *FlashbotLaunch) logf(format string, v ...interface{}) {
+	if f.logger != nil {
+		f.logger.Printf(format, v...)
+		return
+	}
+	log.Printf(format, v...)
+}
+
+// decodeResponse unmarshals a relay response into v. In debug mode every
+// field of the response the package does not model yet is logged instead
+// of silently dropped, and the response is checked
+// against the embedded response schema so that changed field types or
+// missing fields are logged before they cause silent parsing bugs.
+func (f *FlashbotLaunch) decodeResponse(method string, resp []byte, v interface{}) error {
+	if f.debug {
+		for _, field := range unknownFields(resp, reflect.TypeOf(v), "") {
+			f.logf("flashbot: %s response has unmodelled field %s", method, field)
+		}
+
+		mismatches, err := validateResponse(method, resp)
+		if err != nil {
+			f.logf("flashbot: %s response could not be checked against its schema: %v", method, err)
+		}
+		for _, mismatch := range mismatches {
+			f.logf("flashbot: %s response does not match its schema: %s", method, mismatch)
+		}
+	}
+
+	err := json.Unmarshal(resp, v)
+
+	// A syntax error at the very end of the body means it was cut short.
+	var syntaxErr *json.SyntaxError
+	if errors.As(err, &syntaxErr) && syntaxErr.Offset >= int64(len(resp)) {
+		return fmt.Errorf("%w: %s: %v", ErrTruncatedResponse, method, err)
+	}
+
+	return err
+}
+
+// unknownFields returns the path of every object key in data that t has no
+// field for, matched case-insensitively like encoding/json does. Values
+// that do not decode as the expected shape, and types decoding themselves,
+// are not looked into.
+func unknownFields(data []byte, t reflect.Type, path string) []string {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if reflect.PtrTo(t).Implements(reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()) {
+		return nil
+	}
+
+	var unknown []string
+	switch t.Kind() {
+	case reflect.Struct:
+		var object map[string]json.RawMessage
+		if json.Unmarshal(data, &object) != nil {
+			return nil
+		}
+		fields := jsonFields(t)
+		for key, value := range object {
+			field, ok := fields[strings.ToLower(key)]
+			if !ok {
+				unknown = append(unknown, joinPath(path, key))
+				continue
+			}
+			unknown = append(unknown, unknownFields(value, field, joinPath(path, key))...)
+		}
+	case reflect.Slice, reflect.Array:
+		var elems []json.RawMessage
+		if json.Unmarshal(data, &elems) != nil {
+			return nil
+		}
+		for i, elem := range elems {
+			unknown = append(unknown, unknownFields(elem, t.Elem(), path+"["+strconv.Itoa(i)+"]")...)
+		}
+	case reflect.Map:
+		var object map[string]json.RawMessage
+		if json.Unmarshal(data, &object) != nil {
+			return nil
+		}
+		for key, value := range object {
+			unknown = append(unknown, unknownFields(value, t.Elem(), joinPath(path, key))...)
+		}
+	}
+
+	sort.Strings(unknown)
+	return unknown
+}
+
+// jsonFields maps the lowercased JSON names of the fields of struct t,
+// including those promoted from embedded structs, to their types.
+func jsonFields(t reflect.Type) map[string]reflect.Type {
+	fields := make(map[string]reflect.Type)
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, _, _ := strings.Cut(tag, ",")
+
+		fieldType := field.Type
+		for fieldType.Kind() == reflect.Ptr {
+			fieldType = fieldType.Elem()
+		}
+		if field.Anonymous && name == "" && fieldType.Kind() == reflect.Struct {
+			for promoted, promotedType := range jsonFields(fieldType) {
+				if _, ok := fields[promoted]; !ok {
+					fields[promoted] = promotedType
+				}
+			}
+			continue
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		fields[strings.ToLower(name)] = field.Type
+	}
+
+	return fields
+}
+
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+// logRequest logs an indented copy of the request envelope. Only the log
+// copy is indented; the signed payload on the wire is left untouched.
+func (f *FlashbotLaunch) logRequest(request metaRequestParams) {
+	pretty, err := json.MarshalIndent(request, "", "  ")
+	if err != nil {
+		f.logf("flashbot: %s request could not be formatted: %v", request.Method, err)
+		return
+	}
+	f.logf("flashbot: %s request to %s:\n%s", request.Method, f.Rpc, pretty)
+}
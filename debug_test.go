@@ -0,0 +1,18 @@
+package flashbot
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestUnknownFields(t *testing.T) {
+	resp := []byte(`{"jsonrpc":"2.0","id":1,"meta":{},"result":{` +
+		`"BUNDLEHASH":"0x01","bundleGasPrice":"1","builder":"titan",` +
+		`"results":[{"gasUsed":21000},{"gasUsed":21000,"logs":[],"revert":"0x"}]}}`)
+
+	got := unknownFields(resp, reflect.TypeOf(new(CallBundleResponse)), "")
+	want := []string{"meta", "result.builder", "result.results[1].revert"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("unknownFields = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,154 @@
+package flashbot
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// DecodedCallResult is CallResult with every field parsed: amounts in wei
+// as *big.Int, addresses and hashes as go-ethereum types.
+type DecodedCallResult struct {
+	BundleGasPrice    *big.Int
+	BundleHash        common.Hash
+	CoinbaseDiff      *big.Int
+	EthSentToCoinbase *big.Int
+	GasFees           *big.Int
+	Results           []DecodedTxResult
+	StateBlockNumber  uint64
+	TotalGasUsed      uint64
+	// StateRoot is nil unless the relay reports it.
+	StateRoot *common.Hash
+}
+
+// DecodedTxResult is TxResult with every field parsed.
+type DecodedTxResult struct {
+	CoinbaseDiff      *big.Int
+	EthSentToCoinbase *big.Int
+	From              common.Address
+	// To is nil for contract creations.
+	To       *common.Address
+	GasFees  *big.Int
+	GasPrice *big.Int
+	GasUsed  uint64
+	TxHash   common.Hash
+	Value    *big.Int
+	Error    string
+	Logs     []Log
+	// MaxFeePerGas and MaxPriorityFeePerGas are nil unless the relay
+	// reports them.
+	MaxFeePerGas         *big.Int
+	MaxPriorityFeePerGas *big.Int
+	AccessList           types.AccessList
+}
+
+// Decode parses the simulation result into a DecodedCallResult, failing
+// with the name of a malformed field.
+func (r *CallBundleResponse) Decode() (*DecodedCallResult, error) {
+	if err := r.Err(); err != nil {
+		return nil, err
+	}
+	res := r.Result
+
+	decoded := &DecodedCallResult{
+		Results:          make([]DecodedTxResult, len(res.Results)),
+		StateBlockNumber: res.StateBlockNumber,
+		TotalGasUsed:     res.TotalGasUsed,
+	}
+
+	var err error
+	if decoded.BundleHash, err = parseHash(res.BundleHash); err != nil {
+		return nil, fmt.Errorf("bundleHash: %w", err)
+	}
+	if err := parseQuantities([]quantityField{
+		{"bundleGasPrice", res.BundleGasPrice, &decoded.BundleGasPrice},
+		{"coinbaseDiff", res.CoinbaseDiff, &decoded.CoinbaseDiff},
+		{"ethSentToCoinbase", res.EthSentToCoinbase, &decoded.EthSentToCoinbase},
+		{"gasFees", res.GasFees, &decoded.GasFees},
+	}); err != nil {
+		return nil, err
+	}
+	if res.StateRoot != "" {
+		root, err := parseHash(res.StateRoot)
+		if err != nil {
+			return nil, fmt.Errorf("stateRoot: %w", err)
+		}
+		decoded.StateRoot = &root
+	}
+
+	for i := range res.Results {
+		if decoded.Results[i], err = res.Results[i].decode(); err != nil {
+			return nil, fmt.Errorf("results[%d]: %w", i, err)
+		}
+	}
+
+	return decoded, nil
+}
+
+func (r *TxResult) decode() (DecodedTxResult, error) {
+	decoded := DecodedTxResult{
+		GasUsed:    r.GasUsed,
+		Error:      r.Error,
+		Logs:       r.Logs,
+		AccessList: r.AccessList,
+	}
+
+	if !common.IsHexAddress(r.FromAddress) {
+		return decoded, fmt.Errorf("fromAddress: invalid address %q", r.FromAddress)
+	}
+	decoded.From = common.HexToAddress(r.FromAddress)
+	if r.ToAddress != "" {
+		if !common.IsHexAddress(r.ToAddress) {
+			return decoded, fmt.Errorf("toAddress: invalid address %q", r.ToAddress)
+		}
+		to := common.HexToAddress(r.ToAddress)
+		decoded.To = &to
+	}
+
+	var err error
+	if decoded.TxHash, err = parseHash(r.TxHash); err != nil {
+		return decoded, fmt.Errorf("txHash: %w", err)
+	}
+	if err := parseQuantities([]quantityField{
+		{"coinbaseDiff", r.CoinbaseDiff, &decoded.CoinbaseDiff},
+		{"ethSentToCoinbase", r.EthSentToCoinbase, &decoded.EthSentToCoinbase},
+		{"gasFees", r.GasFees, &decoded.GasFees},
+		{"gasPrice", r.GasPrice, &decoded.GasPrice},
+		{"value", r.Value, &decoded.Value},
+	}); err != nil {
+		return decoded, err
+	}
+
+	if decoded.MaxFeePerGas, err = r.MaxFeePerGasWei(); err != nil {
+		return decoded, fmt.Errorf("maxFeePerGas: %w", err)
+	}
+	if decoded.MaxPriorityFeePerGas, err = r.MaxPriorityFeePerGasWei(); err != nil {
+		return decoded, fmt.Errorf("maxPriorityFeePerGas: %w", err)
+	}
+
+	return decoded, nil
+}
+
+// quantityField is a named quantity string and where to store its parsed
+// value.
+type quantityField struct {
+	name     string
+	quantity string
+	dst      **big.Int
+}
+
+// parseQuantities parses fields in order, so the first malformed field is
+// the one reported.
+func parseQuantities(fields []quantityField) error {
+	for _, field := range fields {
+		value, err := parseQuantity(field.quantity)
+		if err != nil {
+			return fmt.Errorf("%s: %w", field.name, err)
+		}
+		*field.dst = value
+	}
+
+	return nil
+}
@@ -0,0 +1,146 @@
+package flashbot
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// CallResultDiff is how simulation b differs from simulation a. Amounts are
+// b minus a, in wei.
+type CallResultDiff struct {
+	CoinbaseDiff      *big.Int
+	EthSentToCoinbase *big.Int
+	GasFees           *big.Int
+	BundleGasPrice    *big.Int
+	TotalGasUsed      int64
+	// Transactions compares the transactions position by position, as
+	// re-signed transactions change hash. Unchanged ones are left out.
+	Transactions []TxResultDiff
+	// Added and Removed count the transactions only b, or only a, has at
+	// the end of the bundle.
+	Added   int
+	Removed int
+}
+
+// TxResultDiff is how the transaction at Index differs between two
+// simulations. ErrorA and ErrorB are its errors in each, empty on success.
+type TxResultDiff struct {
+	Index        int
+	TxHashA      common.Hash
+	TxHashB      common.Hash
+	GasUsed      int64
+	CoinbaseDiff *big.Int
+	ErrorA       string
+	ErrorB       string
+}
+
+// Changed reports whether the diff has any difference.
+func (d CallResultDiff) Changed() bool {
+	return nonZero(d.CoinbaseDiff) || nonZero(d.EthSentToCoinbase) ||
+		nonZero(d.GasFees) || nonZero(d.BundleGasPrice) || d.TotalGasUsed != 0 ||
+		len(d.Transactions) > 0 || d.Added > 0 || d.Removed > 0
+}
+
+func nonZero(x *big.Int) bool {
+	return x != nil && x.Sign() != 0
+}
+
+// DiffCallResults compares two simulations of a bundle, e.g. before and
+// after a change to how it is built. Both must be successful simulations.
+func DiffCallResults(a, b *CallBundleResponse) (CallResultDiff, error) {
+	decodedA, err := a.Decode()
+	if err != nil {
+		return CallResultDiff{}, fmt.Errorf("a: %w", err)
+	}
+	decodedB, err := b.Decode()
+	if err != nil {
+		return CallResultDiff{}, fmt.Errorf("b: %w", err)
+	}
+
+	diff := CallResultDiff{
+		CoinbaseDiff:      new(big.Int).Sub(decodedB.CoinbaseDiff, decodedA.CoinbaseDiff),
+		EthSentToCoinbase: new(big.Int).Sub(decodedB.EthSentToCoinbase, decodedA.EthSentToCoinbase),
+		GasFees:           new(big.Int).Sub(decodedB.GasFees, decodedA.GasFees),
+		BundleGasPrice:    new(big.Int).Sub(decodedB.BundleGasPrice, decodedA.BundleGasPrice),
+		TotalGasUsed:      int64(decodedB.TotalGasUsed) - int64(decodedA.TotalGasUsed),
+	}
+
+	shared := len(decodedA.Results)
+	if len(decodedB.Results) < shared {
+		shared = len(decodedB.Results)
+	}
+	for i := 0; i < shared; i++ {
+		txA, txB := decodedA.Results[i], decodedB.Results[i]
+		txDiff := TxResultDiff{
+			Index:        i,
+			TxHashA:      txA.TxHash,
+			TxHashB:      txB.TxHash,
+			GasUsed:      int64(txB.GasUsed) - int64(txA.GasUsed),
+			CoinbaseDiff: new(big.Int).Sub(txB.CoinbaseDiff, txA.CoinbaseDiff),
+			ErrorA:       txA.Error,
+			ErrorB:       txB.Error,
+		}
+		if txDiff.GasUsed != 0 || txDiff.CoinbaseDiff.Sign() != 0 || txDiff.ErrorA != txDiff.ErrorB {
+			diff.Transactions = append(diff.Transactions, txDiff)
+		}
+	}
+	diff.Added = len(decodedB.Results) - shared
+	diff.Removed = len(decodedA.Results) - shared
+
+	return diff, nil
+}
+
+// String renders the differences one per line, or "no difference".
+func (d CallResultDiff) String() string {
+	if !d.Changed() {
+		return "no difference"
+	}
+
+	var lines []string
+	addAmount := func(name string, delta *big.Int) {
+		if nonZero(delta) {
+			lines = append(lines, fmt.Sprintf("%s: %+d wei", name, delta))
+		}
+	}
+	addAmount("coinbaseDiff", d.CoinbaseDiff)
+	addAmount("ethSentToCoinbase", d.EthSentToCoinbase)
+	addAmount("gasFees", d.GasFees)
+	addAmount("bundleGasPrice", d.BundleGasPrice)
+	if d.TotalGasUsed != 0 {
+		lines = append(lines, fmt.Sprintf("totalGasUsed: %+d", d.TotalGasUsed))
+	}
+
+	for _, tx := range d.Transactions {
+		var changes []string
+		if tx.GasUsed != 0 {
+			changes = append(changes, fmt.Sprintf("gasUsed %+d", tx.GasUsed))
+		}
+		if nonZero(tx.CoinbaseDiff) {
+			changes = append(changes, fmt.Sprintf("coinbaseDiff %+d wei", tx.CoinbaseDiff))
+		}
+		if tx.ErrorA != tx.ErrorB {
+			changes = append(changes, fmt.Sprintf("error %s -> %s", outcome(tx.ErrorA), outcome(tx.ErrorB)))
+		}
+		lines = append(lines, fmt.Sprintf("tx %d: %s", tx.Index, strings.Join(changes, ", ")))
+	}
+
+	if d.Added > 0 {
+		lines = append(lines, fmt.Sprintf("%d transactions added", d.Added))
+	}
+	if d.Removed > 0 {
+		lines = append(lines, fmt.Sprintf("%d transactions removed", d.Removed))
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// outcome quotes a transaction error, or reports success.
+func outcome(txErr string) string {
+	if txErr == "" {
+		return "success"
+	}
+	return fmt.Sprintf("%q", txErr)
+}
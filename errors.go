@@ -0,0 +1,111 @@
+package flashbot
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"time"
+)
+
+// ErrTruncatedResponse is returned when the relay connection dropped before
+// the whole response body arrived. The request may be retried.
+var ErrTruncatedResponse = errors.New("truncated relay response")
+
+// ErrResponseTooLarge is returned when a response body exceeds the maximum
+// size, see WithMaxResponseSize.
+var ErrResponseTooLarge = errors.New("response body too large")
+
+// CanceledError is returned when the context of a request is done before
+// the response arrived. Sent tells whether the whole request had been
+// written to a relay by then: if so the relay may still act on it, and a
+// bundle or private transaction may need to be cancelled or deduplicated.
+type CanceledError struct {
+	Method string
+	Sent   bool
+	Err    error
+}
+
+func (e *CanceledError) Error() string {
+	if e.Sent {
+		return e.Method + " canceled after the request was sent: " + e.Err.Error()
+	}
+	return e.Method + " canceled before the request was sent: " + e.Err.Error()
+}
+
+func (e *CanceledError) Unwrap() error {
+	return e.Err
+}
+
+// RateLimitedError is returned when the relay answered 429 Too Many
+// Requests. RetryAfter is the delay the relay asked for in its Retry-After
+// header, or 0 when it sent none.
+type RateLimitedError struct {
+	Method     string
+	RetryAfter time.Duration
+}
+
+func (e *RateLimitedError) Error() string {
+	if e.RetryAfter > 0 {
+		return fmt.Sprintf("%s rate limited by the relay, retry after %s", e.Method, e.RetryAfter)
+	}
+	return e.Method + " rate limited by the relay"
+}
+
+// IsRetryable reports whether err is a transient transport failure, such
+// as a truncated response, a network timeout or relay rate limiting, after
+// which the same request can be sent again.
+func IsRetryable(err error) bool {
+	if errors.Is(err, ErrTruncatedResponse) {
+		return true
+	}
+
+	var rateErr *RateLimitedError
+	if errors.As(err, &rateErr) {
+		return true
+	}
+
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
+
+// RelayErrorData returns the raw data field of the relay error wrapped in
+// err, such as revert details. It returns nil when err carries no relay
+// error or the relay sent no data.
+func RelayErrorData(err error) json.RawMessage {
+	var rpcErr *errorResult
+	if !errors.As(err, &rpcErr) {
+		return nil
+	}
+	return rpcErr.Data
+}
+
+// DecodeRelayErrorData decodes the data field of the relay error wrapped in
+// err into v. It reports false when there is no data to decode.
+func DecodeRelayErrorData(err error, v interface{}) (bool, error) {
+	data := RelayErrorData(err)
+	if len(data) == 0 || bytes.Equal(data, []byte("null")) {
+		return false, nil
+	}
+	return true, json.Unmarshal(data, v)
+}
+
+// dataString renders the error data for Error: strings are unquoted, other
+// values are compacted JSON.
+func (e *errorResult) dataString() string {
+	if len(e.Data) == 0 || bytes.Equal(e.Data, []byte("null")) {
+		return ""
+	}
+
+	var text string
+	if err := json.Unmarshal(e.Data, &text); err == nil {
+		return text
+	}
+
+	var compact bytes.Buffer
+	if err := json.Compact(&compact, e.Data); err != nil {
+		return string(e.Data)
+	}
+	return compact.String()
+}
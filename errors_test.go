@@ -0,0 +1,105 @@
+package flashbot
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// partialBody is the start of a user stats response, cut mid-object.
+const partialBody = `{"jsonrpc":"2.0","id":1,"result":{"is_high_priority":tr`
+
+func TestTruncatedResponse(t *testing.T) {
+	tests := []struct {
+		name    string
+		handler http.HandlerFunc
+	}{
+		{
+			// The connection drops before Content-Length bytes arrived.
+			name: "connection closed mid-body",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				conn, buf, err := w.(http.Hijacker).Hijack()
+				if err != nil {
+					t.Error(err)
+					return
+				}
+				defer conn.Close()
+				buf.WriteString("HTTP/1.1 200 OK\r\nContent-Type: application/json\r\nContent-Length: 200\r\n\r\n")
+				buf.WriteString(partialBody)
+				buf.Flush()
+			},
+		},
+		{
+			// The relay ends a complete HTTP response with a cut JSON body.
+			name: "body cut short",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				io.WriteString(w, partialBody)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			relay := httptest.NewServer(tt.handler)
+			defer relay.Close()
+			f := newTestClient(t, relay.URL)
+
+			_, err := f.GetUserStats(17000000)
+			if !errors.Is(err, ErrTruncatedResponse) {
+				t.Fatalf("err = %v, want ErrTruncatedResponse", err)
+			}
+			if !IsRetryable(err) {
+				t.Errorf("IsRetryable(%v) = false, want true", err)
+			}
+		})
+	}
+}
+
+func TestSendBundleContextCanceled(t *testing.T) {
+	received := make(chan struct{})
+	relay := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.Copy(io.Discard, r.Body)
+		close(received)
+		<-r.Context().Done()
+	}))
+	defer relay.Close()
+	f := newTestClient(t, relay.URL)
+	tx := signedTestTx(t, 0)
+
+	t.Run("before send", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		_, err := f.SendBundleContext(ctx, []string{tx}, 17000000)
+		var canceled *CanceledError
+		if !errors.As(err, &canceled) {
+			t.Fatalf("err = %v, want *CanceledError", err)
+		}
+		if canceled.Sent {
+			t.Error("Sent = true for a request canceled before it was written")
+		}
+	})
+
+	t.Run("after send", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		go func() {
+			<-received
+			cancel()
+		}()
+
+		_, err := f.SendBundleContext(ctx, []string{tx}, 17000000)
+		var canceled *CanceledError
+		if !errors.As(err, &canceled) {
+			t.Fatalf("err = %v, want *CanceledError", err)
+		}
+		if !canceled.Sent {
+			t.Error("Sent = false for a request the relay received")
+		}
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("err = %v, want it to wrap context.Canceled", err)
+		}
+	})
+}
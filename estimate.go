@@ -0,0 +1,153 @@
+package flashbot
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// CallTx is an unsigned transaction described by its fields, as taken by
+// eth_estimateGasBundle. Zero values are left out and filled in by the
+// relay; Nonce is a pointer so that nonce 0 can be sent.
+type CallTx struct {
+	From     common.Address
+	To       *common.Address
+	Gas      uint64
+	GasPrice *big.Int
+	// MaxFeePerGas and MaxPriorityFeePerGas describe a dynamic fee
+	// transaction in place of GasPrice.
+	MaxFeePerGas         *big.Int
+	MaxPriorityFeePerGas *big.Int
+	Value                *big.Int
+	Nonce                *uint64
+	Data                 []byte
+	AccessList           types.AccessList
+}
+
+// MarshalJSON encodes the numeric fields as 0x prefixed quantities.
+func (tx CallTx) MarshalJSON() ([]byte, error) {
+	type callTx struct {
+		From                 common.Address    `json:"from"`
+		To                   *common.Address   `json:"to,omitempty"`
+		Gas                  hexutil.Uint64    `json:"gas,omitempty"`
+		GasPrice             *hexutil.Big      `json:"gasPrice,omitempty"`
+		MaxFeePerGas         *hexutil.Big      `json:"maxFeePerGas,omitempty"`
+		MaxPriorityFeePerGas *hexutil.Big      `json:"maxPriorityFeePerGas,omitempty"`
+		Value                *hexutil.Big      `json:"value,omitempty"`
+		Nonce                *hexutil.Uint64   `json:"nonce,omitempty"`
+		Data                 hexutil.Bytes     `json:"data,omitempty"`
+		AccessList           *types.AccessList `json:"accessList,omitempty"`
+	}
+
+	enc := callTx{
+		From:                 tx.From,
+		To:                   tx.To,
+		Gas:                  hexutil.Uint64(tx.Gas),
+		GasPrice:             (*hexutil.Big)(tx.GasPrice),
+		MaxFeePerGas:         (*hexutil.Big)(tx.MaxFeePerGas),
+		MaxPriorityFeePerGas: (*hexutil.Big)(tx.MaxPriorityFeePerGas),
+		Value:                (*hexutil.Big)(tx.Value),
+		Nonce:                (*hexutil.Uint64)(tx.Nonce),
+		Data:                 tx.Data,
+	}
+	if len(tx.AccessList) > 0 {
+		enc.AccessList = &tx.AccessList
+	}
+
+	return json.Marshal(enc)
+}
+
+// validate checks the fee fields and the access list of the transaction.
+// Repeated access list addresses or storage keys are valid on chain but
+// charged twice, so they are rejected as a likely mistake.
+func (tx CallTx) validate() error {
+	if tx.GasPrice != nil && (tx.MaxFeePerGas != nil || tx.MaxPriorityFeePerGas != nil) {
+		return errors.New("gasPrice cannot be combined with maxFeePerGas or maxPriorityFeePerGas")
+	}
+
+	addresses := make(map[common.Address]bool, len(tx.AccessList))
+	for i, tuple := range tx.AccessList {
+		if addresses[tuple.Address] {
+			return fmt.Errorf("accessList[%d]: duplicate address %s", i, tuple.Address.Hex())
+		}
+		addresses[tuple.Address] = true
+
+		keys := make(map[common.Hash]bool, len(tuple.StorageKeys))
+		for j, key := range tuple.StorageKeys {
+			if keys[key] {
+				return fmt.Errorf("accessList[%d].storageKeys[%d]: duplicate key %s", i, j, key.Hex())
+			}
+			keys[key] = true
+		}
+	}
+
+	return nil
+}
+
+type EstimateGasBundleParams struct {
+	Transactions     []CallTx `json:"txs"`
+	BlockNumber      string   `json:"blockNumber"`
+	StateBlockNumber string   `json:"stateBlockNumber"`
+	Timestamp        int64    `json:"timestamp,omitempty"`
+}
+
+type EstimateGasBundleResponse struct {
+	ID      uint            `json:"id"`
+	Version string          `json:"jsonrpc"`
+	Result  *EstimateResult `json:"result"`
+	Error   *errorResult    `json:"error"`
+}
+
+type EstimateResult struct {
+	Results []EstimateTxResult `json:"results"`
+}
+
+type EstimateTxResult struct {
+	GasUsed uint64 `json:"gasUsed"`
+}
+
+// Err returns the error of a failed eth_estimateGasBundle call, or nil.
+func (r *EstimateGasBundleResponse) Err() error {
+	return checkRPCError(r.Error, r.Result)
+}
+
+// EstimateGasBundle estimates the gas every transaction of the bundle uses
+// when it is executed in order for blockNumber on top of the latest state.
+// A block number of 0 targets the next block.
+func (f *FlashbotLaunch) EstimateGasBundle(transactions []CallTx, blockNumber uint64) (*EstimateGasBundleResponse, error) {
+	if len(transactions) < 1 {
+		return nil, errorTransaction
+	}
+	for i, tx := range transactions {
+		if err := tx.validate(); err != nil {
+			return nil, fmt.Errorf("transaction %d: %w", i, err)
+		}
+	}
+
+	blockNumber, err := f.resolveBlock(blockNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	args := EstimateGasBundleParams{
+		Transactions:     transactions,
+		BlockNumber:      HextoBlockNumber(blockNumber),
+		StateBlockNumber: "latest",
+	}
+
+	resp, err := f.requestRPC(MethodEstimateGasBundle, args)
+	if err != nil {
+		return nil, err
+	}
+	estimateResp := new(EstimateGasBundleResponse)
+	if err := f.decodeResponse(MethodEstimateGasBundle, resp, estimateResp); err != nil {
+		return nil, err
+	}
+
+	return estimateResp, nil
+}
@@ -0,0 +1,128 @@
+package flashbot
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+func TestCallTxMarshalJSON(t *testing.T) {
+	from := common.HexToAddress("0x000000000000000000000000000000000000bEEF")
+	to := common.HexToAddress("0x000000000000000000000000000000000000dEaD")
+	nonce, zero := uint64(7), uint64(0)
+
+	tests := []struct {
+		name string
+		tx   CallTx
+		want string
+	}{
+		{
+			name: "zero values left out",
+			tx:   CallTx{From: from},
+			want: `{"from":"0x000000000000000000000000000000000000beef"}`,
+		},
+		{
+			name: "legacy",
+			tx: CallTx{
+				From:     from,
+				To:       &to,
+				Gas:      21000,
+				GasPrice: big.NewInt(30e9),
+				Value:    new(big.Int).Exp(big.NewInt(10), big.NewInt(18), nil),
+				Nonce:    &nonce,
+				Data:     []byte{0xa9, 0x05, 0x9c, 0xbb},
+			},
+			want: `{"from":"0x000000000000000000000000000000000000beef","to":"0x000000000000000000000000000000000000dead",` +
+				`"gas":"0x5208","gasPrice":"0x6fc23ac00","value":"0xde0b6b3a7640000","nonce":"0x7","data":"0xa9059cbb"}`,
+		},
+		{
+			name: "nonce zero",
+			tx:   CallTx{From: from, Nonce: &zero},
+			want: `{"from":"0x000000000000000000000000000000000000beef","nonce":"0x0"}`,
+		},
+		{
+			name: "dynamic fee",
+			tx: CallTx{
+				From:                 from,
+				Gas:                  100000,
+				MaxFeePerGas:         big.NewInt(50e9),
+				MaxPriorityFeePerGas: big.NewInt(2e9),
+			},
+			want: `{"from":"0x000000000000000000000000000000000000beef","gas":"0x186a0",` +
+				`"maxFeePerGas":"0xba43b7400","maxPriorityFeePerGas":"0x77359400"}`,
+		},
+		{
+			name: "access list",
+			tx: CallTx{
+				From: from,
+				AccessList: types.AccessList{{
+					Address:     to,
+					StorageKeys: []common.Hash{common.HexToHash("0x01")},
+				}},
+			},
+			want: `{"from":"0x000000000000000000000000000000000000beef","accessList":[{"address":"0x000000000000000000000000000000000000dead",` +
+				`"storageKeys":["0x0000000000000000000000000000000000000000000000000000000000000001"]}]}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := json.Marshal(tt.tx)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("got  %s\nwant %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCallTxValidate(t *testing.T) {
+	to := common.HexToAddress("0x000000000000000000000000000000000000dEaD")
+	key := common.HexToHash("0x01")
+
+	tests := []struct {
+		name    string
+		tx      CallTx
+		wantErr bool
+	}{
+		{name: "legacy", tx: CallTx{GasPrice: big.NewInt(1)}},
+		{name: "dynamic fee", tx: CallTx{MaxFeePerGas: big.NewInt(2), MaxPriorityFeePerGas: big.NewInt(1)}},
+		{name: "mixed fees", tx: CallTx{GasPrice: big.NewInt(1), MaxFeePerGas: big.NewInt(2)}, wantErr: true},
+		{name: "duplicate address", tx: CallTx{AccessList: types.AccessList{{Address: to}, {Address: to}}}, wantErr: true},
+		{name: "duplicate key", tx: CallTx{AccessList: types.AccessList{{Address: to, StorageKeys: []common.Hash{key, key}}}}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.tx.validate(); (err != nil) != tt.wantErr {
+				t.Errorf("validate() = %v, want error %t", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestEstimateGasBundleWire(t *testing.T) {
+	relay := newTestRelay(t, `{"jsonrpc":"2.0","id":1,"result":{"results":[{"gasUsed":21000}]}}`)
+	f := newTestClient(t, relay.URL)
+
+	to := common.HexToAddress("0x000000000000000000000000000000000000dEaD")
+	nonce := uint64(1)
+	resp, err := f.EstimateGasBundle([]CallTx{{To: &to, Gas: 21000, Value: big.NewInt(255), Nonce: &nonce}}, 17000000)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Result.Results[0].GasUsed != 21000 {
+		t.Errorf("gasUsed = %d, want 21000", resp.Result.Results[0].GasUsed)
+	}
+
+	want := `[{"txs":[{"from":"0x0000000000000000000000000000000000000000","to":"0x000000000000000000000000000000000000dead",` +
+		`"gas":"0x5208","value":"0xff","nonce":"0x1"}],"blockNumber":"0x1036640","stateBlockNumber":"latest"}]`
+	if got := relay.lastParams(t); got != want {
+		t.Errorf("params =\n%s\nwant\n%s", got, want)
+	}
+}
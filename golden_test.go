@@ -0,0 +1,100 @@
+package flashbot
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden request fixtures in testdata")
+
+// TestRequestGolden compares the exact bytes of each signed request body
+// with a fixture in testdata. The signature covers these bytes, so field
+// order, omitempty behaviour and the params structure must not drift
+// unnoticed. The fixtures are this package's own output, see
+// testdata/README.md.
+func TestRequestGolden(t *testing.T) {
+	tx := signedTestTx(t, 0)
+	second := signedTestTx(t, 1)
+
+	tests := []struct {
+		name string
+		call func(f *FlashbotLaunch)
+	}{
+		{
+			name: "send_bundle",
+			call: func(f *FlashbotLaunch) {
+				f.SendBundle([]string{tx, second}, 17000000, WithReplacementUUID(testUUID))
+			},
+		},
+		{
+			name: "send_bundle_options",
+			call: func(f *FlashbotLaunch) {
+				hashes, err := TxHashes([]string{second})
+				if err != nil {
+					t.Fatal(err)
+				}
+				f.SendBundle([]string{tx, second}, 17000000,
+					WithReplacementUUID(testUUID),
+					WithRevertingTxHashes(hashes...),
+				)
+			},
+		},
+		{
+			name: "call_bundle",
+			call: func(f *FlashbotLaunch) {
+				f.CallBundle([]string{tx, second}, 17000000)
+			},
+		},
+		{
+			name: "call_bundle_options",
+			call: func(f *FlashbotLaunch) {
+				f.CallBundle([]string{tx}, 17000000, WithLogs(), WithAccessList())
+			},
+		},
+		{
+			name: "send_private_tx",
+			call: func(f *FlashbotLaunch) {
+				f.SendPrivateTransaction(tx, "0x1036649")
+			},
+		},
+		{
+			name: "send_private_tx_preferences",
+			call: func(f *FlashbotLaunch) {
+				f.SendPrivateTransaction(tx, "0x1036649", WithPrivacyHints("calldata", "logs"))
+			},
+		},
+		{
+			name: "user_stats",
+			call: func(f *FlashbotLaunch) {
+				f.GetUserStats(17000000)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			relay := newTestRelay(t, `{"jsonrpc":"2.0","id":1,"result":null}`)
+			f := newTestClient(t, relay.URL)
+
+			tt.call(f)
+			got := relay.last(t).Body
+
+			path := filepath.Join("testdata", tt.name+".json")
+			if *update {
+				if err := os.WriteFile(path, append(got, '\n'), 0o644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			want, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if want = bytes.TrimSuffix(want, []byte("\n")); !bytes.Equal(got, want) {
+				t.Errorf("request body differs from %s\ngot:  %s\nwant: %s", path, got, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,134 @@
+package flashbot
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"encoding/json"
+	"io"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// testKeyHex is a well known throwaway key, never used on a real network.
+const testKeyHex = "4c0883a69102937d6231471b5dbb6204fe5129617082792ae468d01a3f362318"
+
+// testUUID is a fixed replacementUuid so request payloads are deterministic.
+const testUUID = "2a3ba7a8-42f3-4f6b-9a6c-41e5d6b51c97"
+
+// testRelay records the requests sent to it and answers every one with the
+// same response.
+type testRelay struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	requests []recordedRequest
+}
+
+type recordedRequest struct {
+	Header http.Header
+	Body   []byte
+}
+
+// newTestRelay starts a relay answering with response, closed when the test
+// ends.
+func newTestRelay(t *testing.T, response string) *testRelay {
+	t.Helper()
+
+	relay := new(testRelay)
+	relay.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		relay.mu.Lock()
+		relay.requests = append(relay.requests, recordedRequest{Header: r.Header.Clone(), Body: body})
+		relay.mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		io.WriteString(w, response)
+	}))
+	t.Cleanup(relay.Close)
+
+	return relay
+}
+
+// last returns the last request the relay received.
+func (r *testRelay) last(t *testing.T) recordedRequest {
+	t.Helper()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if len(r.requests) == 0 {
+		t.Fatal("relay received no request")
+	}
+	return r.requests[len(r.requests)-1]
+}
+
+// lastParams returns the compacted params of the last request.
+func (r *testRelay) lastParams(t *testing.T) string {
+	t.Helper()
+
+	var envelope struct {
+		Params json.RawMessage `json:"params"`
+	}
+	if err := json.Unmarshal(r.last(t).Body, &envelope); err != nil {
+		t.Fatalf("request is not JSON: %v", err)
+	}
+	return compactJSON(t, envelope.Params)
+}
+
+func compactJSON(t *testing.T, data []byte) string {
+	t.Helper()
+
+	var buf bytes.Buffer
+	if err := json.Compact(&buf, data); err != nil {
+		t.Fatalf("invalid JSON %s: %v", data, err)
+	}
+	return buf.String()
+}
+
+// newTestClient returns a client for mainnet signing with the test key and
+// sending to relay.
+func newTestClient(t *testing.T, relay string, opts ...Option) *FlashbotLaunch {
+	t.Helper()
+
+	key, err := crypto.HexToECDSA(testKeyHex)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return newClient(relay, key, append([]Option{WithChainID(big.NewInt(1))}, opts...))
+}
+
+// signedTestTx returns a raw legacy transaction from the test key with the
+// given nonce, signed for mainnet.
+func signedTestTx(t *testing.T, nonce uint64) string {
+	t.Helper()
+
+	key, err := crypto.HexToECDSA(testKeyHex)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return signTestTx(t, key, nonce)
+}
+
+// signTestTx returns a raw legacy transaction from key sending 1 wei with
+// the given nonce, signed for mainnet.
+func signTestTx(t *testing.T, key *ecdsa.PrivateKey, nonce uint64) string {
+	t.Helper()
+
+	to := common.HexToAddress("0x000000000000000000000000000000000000dEaD")
+	tx := types.NewTransaction(nonce, to, big.NewInt(1), 21000, big.NewInt(30e9), nil)
+	signed, err := types.SignTx(tx, types.NewEIP155Signer(big.NewInt(1)), key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	raw, err := signed.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return hexutil.Encode(raw)
+}
@@ -0,0 +1,137 @@
+package flashbot
+
+import (
+	"crypto/ecdsa"
+	"errors"
+	"math/big"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// The integration tests run against a live relay and are skipped unless
+// FLASHBOTS_INTEGRATION=1. They need a mainnet node in FLASHBOTS_NODE_RPC
+// and sign with FLASHBOTS_TEST_KEY, or a fresh key when it is unset. The
+// relay is FLASHBOTS_RELAY, or the mainnet Flashbots relay.
+//
+//	FLASHBOTS_INTEGRATION=1 FLASHBOTS_NODE_RPC=https://... go test -run Integration ./...
+func integrationClient(t *testing.T) (*FlashbotLaunch, *ecdsa.PrivateKey) {
+	t.Helper()
+
+	if os.Getenv("FLASHBOTS_INTEGRATION") != "1" {
+		t.Skip("set FLASHBOTS_INTEGRATION=1 to run against a live relay")
+	}
+	node := os.Getenv("FLASHBOTS_NODE_RPC")
+	if node == "" {
+		t.Fatal("FLASHBOTS_NODE_RPC is not set")
+	}
+
+	var key *ecdsa.PrivateKey
+	var err error
+	if hexKey := os.Getenv("FLASHBOTS_TEST_KEY"); hexKey != "" {
+		key, err = ParsePrivateKey(hexKey)
+	} else {
+		key, err = crypto.GenerateKey()
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	relay := os.Getenv("FLASHBOTS_RELAY")
+	if relay == "" {
+		relay, _ = RelayDefaultRPC("mainnet")
+	}
+
+	return newClient(relay, key, []Option{
+		WithNodeRpc(node),
+		WithChainID(big.NewInt(1)),
+		WithTimeout(30 * time.Second),
+		WithDebug(),
+	}), key
+}
+
+// assertRelayError checks that err is a well formed relay error.
+func assertRelayError(t *testing.T, err error) {
+	t.Helper()
+
+	var rpcErr *errorResult
+	if !errors.As(err, &rpcErr) {
+		t.Fatalf("error is not a relay error: %v", err)
+	}
+	if rpcErr.Code == 0 || rpcErr.Message == "" {
+		t.Errorf("relay error without code or message: %+v", rpcErr)
+	}
+}
+
+func TestIntegrationGetUserStats(t *testing.T) {
+	f, _ := integrationClient(t)
+
+	latest, err := f.LatestBlockNumber()
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := f.GetUserStats(latest)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Error != nil {
+		assertRelayError(t, resp.Error)
+		return
+	}
+	if resp.Result == nil {
+		t.Fatal("response has neither result nor error")
+	}
+
+	for name, value := range map[string]string{
+		"all_time_miner_payments": resp.Result.AllTimeMinerPayments,
+		"all_time_gas_simulated":  resp.Result.AllTimeGasSimulated,
+		"last_7d_miner_payments":  resp.Result.Last7dMinerPayments,
+		"last_1d_gas_simulated":   resp.Result.Last1dGasSimulated,
+	} {
+		if _, err := parseQuantity(value); err != nil {
+			t.Errorf("%s: %v", name, err)
+		}
+	}
+}
+
+func TestIntegrationCallBundle(t *testing.T) {
+	f, key := integrationClient(t)
+
+	latest, err := f.LatestBlockNumber()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// A fresh key has no funds, so the simulation may fail; either way the
+	// relay must answer in a known shape.
+	tx := signTestTx(t, key, 0)
+	resp, err := f.CallBundle([]string{tx}, latest+1)
+	if err != nil {
+		assertRelayError(t, err)
+		return
+	}
+	if resp.Error != nil {
+		assertRelayError(t, resp.Error)
+		return
+	}
+	if resp.Result == nil || len(resp.Result.Results) != 1 {
+		t.Fatalf("got result %+v, want one transaction result", resp.Result)
+	}
+	txHash, err := resp.Result.Results[0].TxHashTyped()
+	if err != nil {
+		t.Fatalf("result tx hash: %v", err)
+	}
+	hashes, err := TxHashes([]string{tx})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := hexutil.Encode(txHash[:]); got != hashes[0] {
+		t.Errorf("result tx hash = %s, want %s", got, hashes[0])
+	}
+	if resp.Result.StateBlockNumber < latest {
+		t.Errorf("stateBlockNumber %d is before the latest block %d", resp.Result.StateBlockNumber, latest)
+	}
+}
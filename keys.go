@@ -0,0 +1,41 @@
+package flashbot
+
+import (
+	"crypto/ecdsa"
+	"math/rand"
+	"sync/atomic"
+)
+
+// KeyStrategy selects which key of a key pool signs a request.
+type KeyStrategy int
+
+const (
+	// RoundRobin cycles through the pooled keys in order.
+	RoundRobin KeyStrategy = iota
+	// RandomKey picks a pooled key at random for every request.
+	RandomKey
+)
+
+type keyPool struct {
+	signers  []Signer
+	strategy KeyStrategy
+	next     uint64
+}
+
+func newKeyPool(keys []*ecdsa.PrivateKey, strategy KeyStrategy) *keyPool {
+	signers := make([]Signer, len(keys))
+	for i, key := range keys {
+		signers[i] = newKeySigner(key)
+	}
+
+	return &keyPool{signers: signers, strategy: strategy}
+}
+
+func (p *keyPool) pick() Signer {
+	if p.strategy == RandomKey {
+		return p.signers[rand.Intn(len(p.signers))]
+	}
+
+	n := atomic.AddUint64(&p.next, 1) - 1
+	return p.signers[n%uint64(len(p.signers))]
+}
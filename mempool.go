@@ -0,0 +1,55 @@
+package flashbot
+
+import "context"
+
+// PendingTxSource is the integration point for a mempool watcher: it
+// delivers the raw signed pending transactions it observes.
+type PendingTxSource interface {
+	// PendingTxs returns the channel of observed transactions. It is
+	// closed when the source stops.
+	PendingTxs() <-chan string
+}
+
+// BackrunFunc builds the bundle and target block for an observed pending
+// transaction, typically the pending transaction followed by a backrun.
+// Returning an empty bundle skips the transaction.
+type BackrunFunc func(pendingTx string) (bundle []string, blockNumber uint64, err error)
+
+// BackrunResult is the outcome of handling one pending transaction.
+type BackrunResult struct {
+	PendingTx   string
+	BlockNumber uint64
+	Response    *SendBundleResponse
+	Err         error
+}
+
+// SubmitBackruns sends a bundle built by build for every transaction from
+// source until the source closes or ctx is done. onResult, when not nil,
+// receives the outcome of every submitted or failed bundle.
+func (f *FlashbotLaunch) SubmitBackruns(ctx context.Context, source PendingTxSource, build BackrunFunc, onResult func(BackrunResult)) error {
+	pending := source.PendingTxs()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case tx, ok := <-pending:
+			if !ok {
+				return nil
+			}
+
+			result := BackrunResult{PendingTx: tx}
+			var bundle []string
+			bundle, result.BlockNumber, result.Err = build(tx)
+			if result.Err == nil {
+				if len(bundle) == 0 {
+					continue
+				}
+				result.Response, result.Err = f.SendBundleContext(ctx, bundle, result.BlockNumber)
+			}
+
+			if onResult != nil {
+				onResult(result)
+			}
+		}
+	}
+}
@@ -0,0 +1,116 @@
+package flashbot
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// errorCodeMethodNotFound is the JSON-RPC error code for unknown methods.
+const errorCodeMethodNotFound = -32601
+
+// SupportedMethods returns the relay methods this package implements.
+func SupportedMethods() []string {
+	return []string{
+		MethodSendBundle,
+		MethodCancelBundle,
+		MethodMevSendBundle,
+		MethodCallBundle,
+		MethodSendPrivateTransaction,
+		MethodCancelPrivateTransaction,
+		MethodEstimateGasBundle,
+		MethodGetUserStats,
+		MethodGetBundleStats,
+		MethodGetBundleStatsV2,
+		MethodSetFeeRefundRecipient,
+		MethodGetFeeRefundTotalsByRecipient,
+	}
+}
+
+// ProbeError lists the methods ProbeMethods could not probe, such as ones
+// rate limited or answered with something other than JSON-RPC.
+type ProbeError struct {
+	Failed map[string]error
+}
+
+func (e *ProbeError) Error() string {
+	msg := fmt.Sprintf("probing %d relay methods failed", len(e.Failed))
+	for _, method := range SupportedMethods() {
+		if err, ok := e.Failed[method]; ok {
+			msg += fmt.Sprintf("; %s: %v", method, err)
+		}
+	}
+	return msg
+}
+
+// ProbeMethods reports which of SupportedMethods the relay knows. Every
+// method is called once without params; the call is expected to fail, and
+// only a "method not found" error marks the method as unsupported.
+//
+// A method that could not be probed is left out of the map and recorded
+// in the returned *ProbeError, and probing goes on with the next one. It
+// stops with the context's error when ctx is done.
+func (f *FlashbotLaunch) ProbeMethods(ctx context.Context) (map[string]bool, error) {
+	methods := SupportedMethods()
+	supported := make(map[string]bool, len(methods))
+	failed := make(map[string]error)
+	for _, method := range methods {
+		resp, err := f.requestRPCContext(ctx, method)
+		if err != nil {
+			if ctx.Err() != nil {
+				return supported, fmt.Errorf("%s: %w", method, err)
+			}
+			failed[method] = err
+			continue
+		}
+
+		var probe struct {
+			Error *errorResult `json:"error"`
+		}
+		if err := json.Unmarshal(resp, &probe); err != nil {
+			failed[method] = err
+			continue
+		}
+		supported[method] = probe.Error == nil || probe.Error.Code != errorCodeMethodNotFound
+	}
+
+	if len(failed) > 0 {
+		return supported, &ProbeError{Failed: failed}
+	}
+	return supported, nil
+}
+
+// RawCall sends a signed request for any relay method, for methods this
+// package does not model yet. params is sent as the JSON-RPC params as is,
+// typically a slice such as []interface{}{args}; nil sends an empty list.
+// It returns the raw result, or the relay's error.
+func (f *FlashbotLaunch) RawCall(method string, params interface{}) (json.RawMessage, error) {
+	if params == nil {
+		params = []interface{}{}
+	}
+
+	requestArgs := metaRequestParams{
+		JsonRPC: "2.0",
+		Id:      1,
+		Method:  method,
+		Params:  params,
+	}
+
+	resp, _, err := f.doRequest(context.Background(), f.requestSigner(), requestArgs)
+	if err != nil {
+		return nil, err
+	}
+
+	var rawResp struct {
+		Result json.RawMessage `json:"result"`
+		Error  *errorResult    `json:"error"`
+	}
+	if err := json.Unmarshal(resp, &rawResp); err != nil {
+		return nil, fmt.Errorf("%s: %w", method, err)
+	}
+	if rawResp.Error != nil {
+		return nil, rawResp.Error
+	}
+
+	return rawResp.Result, nil
+}
@@ -0,0 +1,55 @@
+package flashbot
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestProbeMethodsContinuesAfterFailures(t *testing.T) {
+	relay := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Method string `json:"method"`
+		}
+		body, _ := io.ReadAll(r.Body)
+		json.Unmarshal(body, &req)
+
+		switch req.Method {
+		case MethodGetUserStats:
+			w.WriteHeader(http.StatusTooManyRequests)
+		case MethodCallBundle:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			io.WriteString(w, "<html>405 Not Allowed</html>")
+		case MethodCancelBundle:
+			fmt.Fprintf(w, `{"jsonrpc":"2.0","id":1,"error":{"code":%d,"message":"method not found"}}`, errorCodeMethodNotFound)
+		default:
+			io.WriteString(w, `{"jsonrpc":"2.0","id":1,"error":{"code":-32602,"message":"invalid params"}}`)
+		}
+	}))
+	defer relay.Close()
+	f := newTestClient(t, relay.URL)
+
+	supported, err := f.ProbeMethods(context.Background())
+	var probeErr *ProbeError
+	if !errors.As(err, &probeErr) {
+		t.Fatalf("err = %v, want *ProbeError", err)
+	}
+	if len(probeErr.Failed) != 2 || probeErr.Failed[MethodGetUserStats] == nil || probeErr.Failed[MethodCallBundle] == nil {
+		t.Errorf("failed = %v, want %s and %s", probeErr.Failed, MethodGetUserStats, MethodCallBundle)
+	}
+
+	if len(supported) != len(SupportedMethods())-2 {
+		t.Errorf("probed %d methods, want %d", len(supported), len(SupportedMethods())-2)
+	}
+	if supported[MethodCancelBundle] {
+		t.Errorf("%s reported supported", MethodCancelBundle)
+	}
+	if !supported[MethodSendBundle] {
+		t.Errorf("%s reported unsupported", MethodSendBundle)
+	}
+}
@@ -0,0 +1,134 @@
+package flashbot
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// mevBundleVersion is the mev_sendBundle request version this package speaks.
+const mevBundleVersion = "v0.1"
+
+// ###############
+// mevSendBundle
+// ###############
+type MevSendBundleParams struct {
+	Version   string        `json:"version"`
+	Inclusion MevInclusion  `json:"inclusion"`
+	Body      []BundleEntry `json:"body"`
+	Privacy   *MevPrivacy   `json:"privacy,omitempty"`
+}
+
+// MevPrivacy selects what MEV-Share reveals about a bundle and which
+// builders receive it, in the same shape as the privacy preferences of a
+// private transaction.
+type MevPrivacy = PrivacyPreferences
+
+// MevInclusion is the range of blocks a MEV-Share bundle is valid for.
+type MevInclusion struct {
+	Block    string `json:"block"`
+	MaxBlock string `json:"maxBlock,omitempty"`
+}
+
+// BundleEntry is one transaction of a MEV-Share bundle body: either the
+// hash of a transaction the relay already knows, such as a pending
+// MEV-Share transaction, or a raw signed transaction.
+type BundleEntry struct {
+	Hash string `json:"hash,omitempty"`
+	Tx   string `json:"tx,omitempty"`
+	// CanRevert lets a raw transaction revert without failing the bundle.
+	CanRevert bool `json:"canRevert,omitempty"`
+}
+
+// HashEntry returns a body entry referencing a known transaction by hash.
+func HashEntry(txHash string) BundleEntry {
+	return BundleEntry{Hash: txHash}
+}
+
+// TxEntry returns a body entry holding a raw signed transaction.
+func TxEntry(rawTx string) BundleEntry {
+	return BundleEntry{Tx: rawTx}
+}
+
+func (e BundleEntry) validate() error {
+	switch {
+	case e.Hash != "" && e.Tx != "":
+		return errors.New("entry sets both hash and tx")
+	case e.Hash != "":
+		if e.CanRevert {
+			return errors.New("canRevert is only allowed on tx entries")
+		}
+		raw, err := hexutil.Decode(e.Hash)
+		if err != nil || len(raw) != common.HashLength {
+			return fmt.Errorf("invalid tx hash %q", e.Hash)
+		}
+		return nil
+	case e.Tx != "":
+		_, err := decodeTransaction(e.Tx)
+		return err
+	default:
+		return errors.New("entry sets neither hash nor tx")
+	}
+}
+
+type MevSendBundleResponse struct {
+	ID      uint          `json:"id"`
+	Version string        `json:"jsonrpc"`
+	Result  *BundleResult `json:"result"`
+	Error   *errorResult  `json:"error"`
+}
+
+// Err returns the error of a failed mev_sendBundle call, or nil.
+func (r *MevSendBundleResponse) Err() error {
+	return checkRPCError(r.Error, r.Result)
+}
+
+// SendMevBundle sends a MEV-Share bundle whose body mixes raw transactions
+// and hashes of transactions already known to the relay, valid from
+// blockNumber up to maxBlock. A blockNumber of 0 targets the next block
+// and a maxBlock of 0 limits the bundle to blockNumber. The client's default
+// privacy hints apply unless WithMevPrivacyHints sets others.
+func (f *FlashbotLaunch) SendMevBundle(body []BundleEntry, blockNumber, maxBlock uint64, opts ...MevBundleOption) (*MevSendBundleResponse, error) {
+	if len(body) < 1 {
+		return nil, errorTransaction
+	}
+	for i, entry := range body {
+		if err := entry.validate(); err != nil {
+			return nil, fmt.Errorf("body[%d]: %w", i, err)
+		}
+	}
+
+	blockNumber, err := f.resolveBlock(blockNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	args := MevSendBundleParams{
+		Version:   mevBundleVersion,
+		Inclusion: MevInclusion{Block: HextoBlockNumber(blockNumber)},
+		Body:      body,
+	}
+	if maxBlock != 0 {
+		if maxBlock < blockNumber {
+			return nil, fmt.Errorf("max block %d is before block %d", maxBlock, blockNumber)
+		}
+		args.Inclusion.MaxBlock = HextoBlockNumber(maxBlock)
+	}
+	for _, opt := range opts {
+		opt(&args)
+	}
+	args.Privacy = f.applyPrivacyDefaults(args.Privacy)
+
+	resp, err := f.requestRPC(MethodMevSendBundle, args)
+	if err != nil {
+		return nil, err
+	}
+	mevBundleResp := new(MevSendBundleResponse)
+	if err := f.decodeResponse(MethodMevSendBundle, resp, mevBundleResp); err != nil {
+		return nil, err
+	}
+
+	return mevBundleResp, nil
+}
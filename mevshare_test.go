@@ -0,0 +1,52 @@
+package flashbot
+
+import "testing"
+
+func TestSendMevBundlePrivacy(t *testing.T) {
+	tx := signedTestTx(t, 0)
+	body := []BundleEntry{TxEntry(tx)}
+	prefix := `[{"version":"v0.1","inclusion":{"block":"0x1036640"},"body":[{"tx":"` + tx + `"}]`
+
+	tests := []struct {
+		name string
+		opts []Option
+		call []MevBundleOption
+		want string
+	}{
+		{
+			name: "no privacy",
+			want: prefix + `}]`,
+		},
+		{
+			name: "client default hints",
+			opts: []Option{WithDefaultPrivacyHints("hash", "logs")},
+			want: prefix + `,"privacy":{"hints":["hash","logs"]}}]`,
+		},
+		{
+			name: "hints and builders",
+			opts: []Option{WithDefaultPrivacyHints("hash", "logs")},
+			call: []MevBundleOption{WithMevPrivacyHints("calldata"), WithMevBuilders("flashbots", "Titan")},
+			want: prefix + `,"privacy":{"hints":["calldata"],"builders":["flashbots","Titan"]}}]`,
+		},
+		{
+			name: "builders keep default hints",
+			opts: []Option{WithDefaultPrivacyHints("hash")},
+			call: []MevBundleOption{WithMevBuilders("flashbots")},
+			want: prefix + `,"privacy":{"hints":["hash"],"builders":["flashbots"]}}]`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			relay := newTestRelay(t, `{"jsonrpc":"2.0","id":1,"result":{"bundleHash":"0x01"}}`)
+			f := newTestClient(t, relay.URL, tt.opts...)
+
+			if _, err := f.SendMevBundle(body, 17000000, 0, tt.call...); err != nil {
+				t.Fatal(err)
+			}
+			if got := relay.lastParams(t); got != tt.want {
+				t.Errorf("params =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,258 @@
+package flashbot
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// slotTime is the time between post-merge slots, each holding at most one
+// block.
+const slotTime = 12 * time.Second
+
+// ErrSubmissionTooLate is returned by SendBundle when the bundle targets the
+// next block but too little time is left before it, see
+// WithSubmissionDeadline.
+var ErrSubmissionTooLate = errors.New("submission too late for the target block")
+
+// ############
+// node calls
+// ############
+type nodeResponse struct {
+	Result json.RawMessage `json:"result"`
+	Error  *errorResult    `json:"error"`
+}
+
+// callNode performs a plain, unsigned JSON-RPC call against NodeRpc.
+func (f *FlashbotLaunch) callNode(method string, params ...interface{}) (json.RawMessage, error) {
+	return f.callNodeContext(context.Background(), method, params...)
+}
+
+// callNodeContext is callNode giving up when ctx is done.
+func (f *FlashbotLaunch) callNodeContext(ctx context.Context, method string, params ...interface{}) (json.RawMessage, error) {
+	if f.NodeRpc == "" {
+		return nil, errorNodeMissing
+	}
+	if params == nil {
+		params = []interface{}{}
+	}
+
+	payload, err := json.Marshal(metaRequestParams{
+		JsonRPC: "2.0",
+		Id:      1,
+		Method:  method,
+		Params:  params,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", f.NodeRpc, bytes.NewBuffer(payload))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := f.httpClient().Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := f.readBody(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	nodeResp := new(nodeResponse)
+	if err := json.Unmarshal(body, nodeResp); err != nil {
+		return nil, err
+	}
+	if nodeResp.Error != nil {
+		return nil, fmt.Errorf("%s: %s", method, nodeResp.Error.Message)
+	}
+
+	return nodeResp.Result, nil
+}
+
+// LatestBlockNumber returns the latest block number known to NodeRpc.
+func (f *FlashbotLaunch) LatestBlockNumber() (uint64, error) {
+	return f.latestBlockNumber(context.Background())
+}
+
+func (f *FlashbotLaunch) latestBlockNumber(ctx context.Context) (uint64, error) {
+	result, err := f.callNodeContext(ctx, "eth_blockNumber")
+	if err != nil {
+		return 0, err
+	}
+
+	var hex string
+	if err := json.Unmarshal(result, &hex); err != nil {
+		return 0, err
+	}
+
+	return hexutil.DecodeUint64(hex)
+}
+
+func (f *FlashbotLaunch) validateTargetBlock(blockNumber uint64) error {
+	latest, err := f.LatestBlockNumber()
+	if err != nil {
+		return err
+	}
+
+	if next := latest + 1; blockNumber < next {
+		return fmt.Errorf("target block %d is in the past, next block is %d", blockNumber, next)
+	}
+
+	return nil
+}
+
+// validateMaxBlock checks that a private transaction's maxBlockNumber has
+// not passed yet, since the transaction could never be included.
+func (f *FlashbotLaunch) validateMaxBlock(maxBlockNumber string) error {
+	maxBlock, err := hexutil.DecodeUint64(maxBlockNumber)
+	if err != nil {
+		return fmt.Errorf("invalid maxBlockNumber %q: %w", maxBlockNumber, err)
+	}
+
+	latest, err := f.LatestBlockNumber()
+	if err != nil {
+		return err
+	}
+
+	if maxBlock <= latest {
+		return fmt.Errorf("maxBlockNumber %d is in the past, next block is %d", maxBlock, latest+1)
+	}
+
+	return nil
+}
+
+// resolveBlock returns blockNumber, or the next block when it is zero since
+// the relay never accepts block 0.
+func (f *FlashbotLaunch) resolveBlock(blockNumber uint64) (uint64, error) {
+	return f.resolveBlockContext(context.Background(), blockNumber)
+}
+
+// resolveBlockContext is resolveBlock giving up when ctx is done.
+func (f *FlashbotLaunch) resolveBlockContext(ctx context.Context, blockNumber uint64) (uint64, error) {
+	if blockNumber != 0 {
+		return blockNumber, nil
+	}
+
+	latest, err := f.latestBlockNumber(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("resolve block 0 to the next block: %w", err)
+	}
+
+	return latest + 1, nil
+}
+
+// NextBlockEstimate is when the next block is expected, see
+// EstimateNextBlock.
+type NextBlockEstimate struct {
+	Number uint64
+	At     time.Time
+}
+
+// Remaining returns the time left until the next block is expected.
+func (e *NextBlockEstimate) Remaining() time.Duration {
+	return time.Until(e.At)
+}
+
+// EstimateNextBlock estimates when the block after the latest one known to
+// NodeRpc is built: one slot after the latest block's timestamp, moved on
+// by whole slots when that time passed already, i.e. slots were missed.
+func (f *FlashbotLaunch) EstimateNextBlock() (*NextBlockEstimate, error) {
+	result, err := f.callNode("eth_getBlockByNumber", "latest", false)
+	if err != nil {
+		return nil, err
+	}
+
+	var header struct {
+		Number    hexutil.Uint64 `json:"number"`
+		Timestamp hexutil.Uint64 `json:"timestamp"`
+	}
+	if err := json.Unmarshal(result, &header); err != nil {
+		return nil, err
+	}
+
+	at := time.Unix(int64(header.Timestamp), 0).Add(slotTime)
+	if now := time.Now(); at.Before(now) {
+		missed := now.Sub(at)/slotTime + 1
+		at = at.Add(missed * slotTime)
+	}
+
+	return &NextBlockEstimate{Number: uint64(header.Number) + 1, At: at}, nil
+}
+
+// validateSubmissionTime fails with ErrSubmissionTooLate when blockNumber is
+// the next block and less than margin is left before it.
+func (f *FlashbotLaunch) validateSubmissionTime(blockNumber uint64, margin time.Duration) error {
+	next, err := f.EstimateNextBlock()
+	if err != nil {
+		return err
+	}
+
+	if remaining := next.Remaining(); blockNumber == next.Number && remaining < margin {
+		return fmt.Errorf("%w: block %d expected in %s, deadline margin %s", ErrSubmissionTooLate, blockNumber, remaining.Round(time.Millisecond), margin)
+	}
+
+	return nil
+}
+
+// FetchChainID returns the chain ID reported by NodeRpc through eth_chainId.
+// The first successful answer is cached for the life of the client.
+func (f *FlashbotLaunch) FetchChainID(ctx context.Context) (*big.Int, error) {
+	f.nodeChainMu.Lock()
+	defer f.nodeChainMu.Unlock()
+
+	if f.nodeChainID != nil {
+		return new(big.Int).Set(f.nodeChainID), nil
+	}
+
+	result, err := f.callNodeContext(ctx, "eth_chainId")
+	if err != nil {
+		return nil, err
+	}
+
+	var chainID hexutil.Big
+	if err := json.Unmarshal(result, &chainID); err != nil {
+		return nil, err
+	}
+	f.nodeChainID = chainID.ToInt()
+
+	return new(big.Int).Set(f.nodeChainID), nil
+}
+
+// VerifyBundleChainID checks that every raw signed transaction of the
+// bundle is signed for the chain of NodeRpc, see FetchChainID. Legacy
+// transactions without replay protection name no chain and pass.
+func (f *FlashbotLaunch) VerifyBundleChainID(ctx context.Context, transactions []string) error {
+	chainID, err := f.FetchChainID(ctx)
+	if err != nil {
+		return err
+	}
+
+	txs, err := DecodeBundle(transactions)
+	if err != nil {
+		return err
+	}
+	for i, tx := range txs {
+		if tx.Type() == types.LegacyTxType && !tx.Protected() {
+			continue
+		}
+		if tx.ChainId().Cmp(chainID) != 0 {
+			return fmt.Errorf("transaction %d: chain id %s does not match node chain %s", i, tx.ChainId(), chainID)
+		}
+	}
+
+	return nil
+}
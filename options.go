@@ -0,0 +1,500 @@
+package flashbot
+
+import (
+	"crypto/ecdsa"
+	"crypto/tls"
+	"log"
+	"math/big"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// Option configures a FlashbotLaunch created by New.
+type Option func(*FlashbotLaunch)
+
+// WithNodeRpc sets the Ethereum node used for chain lookups.
+func WithNodeRpc(url string) Option {
+	return func(f *FlashbotLaunch) {
+		f.NodeRpc = url
+	}
+}
+
+// WithTargetBlockCheck makes SendBundle reject bundles whose target block
+// is already behind the next block. It costs one extra node call per
+// bundle and requires WithNodeRpc.
+func WithTargetBlockCheck() Option {
+	return func(f *FlashbotLaunch) {
+		f.checkTargetBlock = true
+	}
+}
+
+// WithNonceCheck makes SendBundle decode its transactions and reject
+// bundles where a sender's nonces do not strictly increase, see
+// ValidateBundleNonces.
+func WithNonceCheck() Option {
+	return func(f *FlashbotLaunch) {
+		f.checkNonces = true
+	}
+}
+
+// WithSubmissionDeadline makes SendBundle fail with ErrSubmissionTooLate
+// when the bundle targets the next block and less than margin is left
+// before that block is expected, see EstimateNextBlock. It costs one extra
+// node call per bundle and requires WithNodeRpc.
+func WithSubmissionDeadline(margin time.Duration) Option {
+	return func(f *FlashbotLaunch) {
+		f.submitMargin = margin
+	}
+}
+
+// WithMaxBlockCheck makes SendPrivateTransaction reject transactions whose
+// maxBlockNumber is already behind the next block. It costs one extra node
+// call per transaction and requires WithNodeRpc.
+func WithMaxBlockCheck() Option {
+	return func(f *FlashbotLaunch) {
+		f.checkMaxBlock = true
+	}
+}
+
+// WithKeyPool signs relay requests with keys chosen by strategy instead of
+// PrivateKey, spreading submissions over several searcher identities. The
+// signer of each request is reported through WithRequestHook. An empty
+// pool is ignored.
+func WithKeyPool(keys []*ecdsa.PrivateKey, strategy KeyStrategy) Option {
+	return func(f *FlashbotLaunch) {
+		if len(keys) == 0 {
+			return
+		}
+		f.keys = newKeyPool(keys, strategy)
+	}
+}
+
+// WithSigner signs relay requests with signer instead of PrivateKey.
+func WithSigner(signer Signer) Option {
+	return func(f *FlashbotLaunch) {
+		f.signer = signer
+	}
+}
+
+// WithSignatureScheme sets how relay requests are signed. Builders that
+// verify a different digest than the Flashbots relay reject requests
+// without saying why, so match the scheme the target documents. The
+// default is SchemeEIP191.
+func WithSignatureScheme(scheme SignatureScheme) Option {
+	return func(f *FlashbotLaunch) {
+		f.scheme = scheme
+	}
+}
+
+// WithChainID sets the chain transactions are signed for. New derives it
+// from the network name otherwise.
+func WithChainID(chainID *big.Int) Option {
+	return func(f *FlashbotLaunch) {
+		f.chainID = chainID
+	}
+}
+
+// WithRequestHook registers hook to be called after every relay request,
+// e.g. for metrics.
+func WithRequestHook(hook func(RequestInfo)) Option {
+	return func(f *FlashbotLaunch) {
+		f.requestHook = hook
+	}
+}
+
+// WithSubmissionRecorder passes a BundleSubmission to recorder for every
+// SendBundle call, including failed ones.
+func WithSubmissionRecorder(recorder SubmissionRecorder) Option {
+	return func(f *FlashbotLaunch) {
+		f.recorder = recorder
+	}
+}
+
+// WithReplacementTracking makes the client remember the replacement nonce
+// of every private transaction it sent, per sender and nonce, and reject a
+// replacement whose nonce does not increase.
+func WithReplacementTracking() Option {
+	return func(f *FlashbotLaunch) {
+		f.replacements = new(replacementTracker)
+	}
+}
+
+// WithCallBundleCache caches successful CallBundle responses for ttl, keyed
+// by the transactions, blocks and options of the call, so re-simulating the
+// same bundle against the same block skips the relay. Cached responses are
+// shared between callers and must not be modified.
+func WithCallBundleCache(ttl time.Duration) Option {
+	return func(f *FlashbotLaunch) {
+		f.callCache = newCallCache(ttl)
+	}
+}
+
+// WithBundleUUID sends the UUID generated for every bundle as its
+// replacementUuid, so that it can be withdrawn with CancelBundle. Without
+// it the UUID is only kept locally, in SendBundleResponse.UUID.
+func WithBundleUUID() Option {
+	return func(f *FlashbotLaunch) {
+		f.sendBundleUUID = true
+	}
+}
+
+// WithDefaultPrivacyHints sets the MEV-Share privacy hints applied to every
+// private transaction and MEV-Share bundle that does not set its own with
+// WithPrivacyHints or WithMevPrivacyHints.
+func WithDefaultPrivacyHints(hints ...string) Option {
+	return func(f *FlashbotLaunch) {
+		f.defaultHints = append([]string(nil), hints...)
+	}
+}
+
+// WithFallbackRelays adds relays tried in order when a request to the
+// previous one gets no response, a truncated one, a 429 or a server error.
+// When every relay answers 429 the last *RateLimitedError is returned. The
+// request is sent with the same signature, which only covers the payload.
+// RequestInfo.Relay tells which relay answered, see WithRequestHook.
+func WithFallbackRelays(relays ...string) Option {
+	return func(f *FlashbotLaunch) {
+		f.fallbackRelays = append([]string(nil), relays...)
+	}
+}
+
+// WithHTTPClient sets the client used for relay and node requests.
+func WithHTTPClient(client *http.Client) Option {
+	return func(f *FlashbotLaunch) {
+		f.client = client
+	}
+}
+
+// WithTimeout sets the timeout of relay and node requests, 20 seconds by default.
+func WithTimeout(timeout time.Duration) Option {
+	return func(f *FlashbotLaunch) {
+		client := *f.httpClient()
+		client.Timeout = timeout
+		f.client = &client
+	}
+}
+
+// WithMaxResponseSize caps the size of relay and node response bodies,
+// 4 MiB by default. Larger responses fail with ErrResponseTooLarge.
+func WithMaxResponseSize(size int64) Option {
+	return func(f *FlashbotLaunch) {
+		f.maxResponseSize = size
+	}
+}
+
+// WithProxy routes relay and node requests through proxy, typically built
+// with http.ProxyURL. Proxy credentials go in the URL user info and are
+// sent as Proxy-Authorization:
+//
+//	u, _ := url.Parse("http://proxy.internal:3128")
+//	u.User = url.UserPassword("user", "pass")
+//	flashbot.New("mainnet", flashbot.WithProxy(http.ProxyURL(u)))
+//
+// The X-Flashbots-Signature header is computed over the request payload
+// before it is sent, so it is unaffected by the proxy.
+func WithProxy(proxy func(*http.Request) (*url.URL, error)) Option {
+	return withTransport(func(t *http.Transport) {
+		t.Proxy = proxy
+	})
+}
+
+// WithConnectionPool sizes the idle connection pool of the client's
+// transport. The defaults keep 64 idle connections, all of which may go to
+// the relay host, for 90 seconds. A custom client passed to WithHTTPClient
+// can set the same fields on its own *http.Transport instead.
+func WithConnectionPool(maxIdleConns, maxIdleConnsPerHost int, idleConnTimeout time.Duration) Option {
+	return withTransport(func(t *http.Transport) {
+		t.MaxIdleConns = maxIdleConns
+		t.MaxIdleConnsPerHost = maxIdleConnsPerHost
+		t.IdleConnTimeout = idleConnTimeout
+	})
+}
+
+// WithRateLimit limits relay requests to rps per second with bursts of up
+// to burst requests. Requests over the limit wait for their turn, or until
+// their context is done, instead of failing. A rate of 0 or less disables
+// the limit.
+func WithRateLimit(rps float64, burst int) Option {
+	return func(f *FlashbotLaunch) {
+		if rps <= 0 {
+			f.limiter = nil
+			return
+		}
+		f.limiter = newRateLimiter(rps, burst)
+	}
+}
+
+// WithInsecureSkipVerify disables TLS certificate verification so a local
+// relay with a self-signed certificate can be used.
+//
+// For testing only: it allows anyone on the network path to read and alter
+// relay traffic. A warning is logged, through WithLogger if given, when a
+// client is created with it.
+func WithInsecureSkipVerify() Option {
+	return withTransport(func(t *http.Transport) {
+		if t.TLSClientConfig == nil {
+			t.TLSClientConfig = &tls.Config{}
+		}
+		t.TLSClientConfig.InsecureSkipVerify = true
+	})
+}
+
+// insecureTLS reports whether the client skips TLS certificate
+// verification, see WithInsecureSkipVerify.
+func (f *FlashbotLaunch) insecureTLS() bool {
+	t, ok := f.httpClient().Transport.(*http.Transport)
+	return ok && t.TLSClientConfig != nil && t.TLSClientConfig.InsecureSkipVerify
+}
+
+// WithBasicAuth sends HTTP Basic Auth credentials to relays gated behind
+// it. Requests keep their X-Flashbots-Signature alongside the
+// Authorization header.
+func WithBasicAuth(user, pass string) Option {
+	return func(f *FlashbotLaunch) {
+		f.basicAuth = &basicAuth{user: user, pass: pass}
+	}
+}
+
+// WithContentType overrides the Content-Type header sent to the relay,
+// application/json by default.
+func WithContentType(contentType string) Option {
+	return func(f *FlashbotLaunch) {
+		f.contentType = contentType
+	}
+}
+
+// WithAccept overrides the Accept header sent to the relay,
+// application/json by default.
+func WithAccept(accept string) Option {
+	return func(f *FlashbotLaunch) {
+		f.accept = accept
+	}
+}
+
+// WithLogger sets the logger used for debug output, the standard logger by default.
+func WithLogger(logger *log.Logger) Option {
+	return func(f *FlashbotLaunch) {
+		f.logger = logger
+	}
+}
+
+// WithDebug enables debug checks. Every field of a relay response the
+// package does not model is logged, and responses are validated against
+// an embedded JSON schema of the expected shape, logging every mismatch.
+func WithDebug() Option {
+	return func(f *FlashbotLaunch) {
+		f.debug = true
+	}
+}
+
+// WithRequestLog logs every outgoing relay request as indented JSON. The
+// signing key and signature header are never logged.
+func WithRequestLog() Option {
+	return func(f *FlashbotLaunch) {
+		f.logRequests = true
+	}
+}
+
+// withTransport applies fn to a copy of the current client's transport,
+// starting from the package defaults when it is not an *http.Transport.
+func withTransport(fn func(*http.Transport)) Option {
+	return func(f *FlashbotLaunch) {
+		client := *f.httpClient()
+
+		transport, ok := client.Transport.(*http.Transport)
+		if !ok {
+			transport = defaultTransport()
+		}
+		transport = transport.Clone()
+		fn(transport)
+
+		client.Transport = transport
+		f.client = &client
+	}
+}
+
+// PrivateTxOption configures a single SendPrivateTransaction call.
+type PrivateTxOption func(*SendPrivateTx)
+
+// WithUseMempool allows the transaction to be sent to the public mempool
+// after its maxBlockNumber, on relays that support it.
+func WithUseMempool() PrivateTxOption {
+	return func(p *SendPrivateTx) {
+		if p.Preferences == nil {
+			p.Preferences = make(map[string]bool)
+		}
+		p.Preferences[PreferenceUseMempool] = true
+	}
+}
+
+// WithReplacementNonce orders replacements of a private transaction: the
+// relay keeps the submission with the highest replacement nonce.
+func WithReplacementNonce(nonce uint64) PrivateTxOption {
+	return func(p *SendPrivateTx) {
+		p.ReplacementNonce = nonce
+	}
+}
+
+// WithPrivacyHints sets the MEV-Share privacy hints of the transaction,
+// replacing the client's defaults. Passing no hints sends an empty list.
+func WithPrivacyHints(hints ...string) PrivateTxOption {
+	return func(p *SendPrivateTx) {
+		if p.Privacy == nil {
+			p.Privacy = new(PrivacyPreferences)
+		}
+		p.Privacy.Hints = append([]string{}, hints...)
+	}
+}
+
+// WithOnlyBuilders restricts which builders receive the transaction, named
+// as MEV-Share lists them. Unknown names are still sent, but logged as a
+// likely typo, see RegisterMevShareBuilder.
+func WithOnlyBuilders(builders ...string) PrivateTxOption {
+	return func(p *SendPrivateTx) {
+		if p.Privacy == nil {
+			p.Privacy = new(PrivacyPreferences)
+		}
+		p.Privacy.OnlyBuilders = append([]string(nil), builders...)
+	}
+}
+
+// WithRefundPercent asks MEV-Share to refund percent percent, 0 to 100, of
+// the MEV the transaction creates to its sender.
+func WithRefundPercent(percent int) PrivateTxOption {
+	return func(p *SendPrivateTx) {
+		p.refundPercent = &percent
+	}
+}
+
+// BundleOption configures a single SendBundle call.
+type BundleOption func(*SendBundleParams)
+
+// WithRevertingTxHashes lists transactions of the bundle that are allowed
+// to revert without invalidating it.
+func WithRevertingTxHashes(hashes ...string) BundleOption {
+	return func(p *SendBundleParams) {
+		p.RevertingTxHashes = append(p.RevertingTxHashes, hashes...)
+	}
+}
+
+// WithDroppingTxHashes lists transactions of the bundle that may be dropped,
+// rather than revert, without invalidating it. Only newer relays support it.
+func WithDroppingTxHashes(hashes ...string) BundleOption {
+	return func(p *SendBundleParams) {
+		p.DroppingTxHashes = append(p.DroppingTxHashes, hashes...)
+	}
+}
+
+// WithReplacementUUID sends uuid as the bundle's replacementUuid in place
+// of the generated one, e.g. to replace or cancel an earlier bundle.
+func WithReplacementUUID(uuid string) BundleOption {
+	return func(p *SendBundleParams) {
+		p.ReplacementUuid = uuid
+	}
+}
+
+// WithSignerIndex signs the bundle with the key at index of the key pool
+// given to WithKeyPool, bypassing the pool strategy, so the bundle is
+// attributed to that identity.
+func WithSignerIndex(index int) BundleOption {
+	return func(p *SendBundleParams) {
+		p.signerIndex = &index
+	}
+}
+
+// WithMinTime sets the bundle's minTimestamp: it is only valid in blocks
+// with a timestamp at or after t. A zero t leaves the bound unset.
+func WithMinTime(t time.Time) BundleOption {
+	return func(p *SendBundleParams) {
+		p.MinTimestamp = unixOrZero(t)
+	}
+}
+
+// WithMaxTime sets the bundle's maxTimestamp: it is only valid in blocks
+// with a timestamp at or before t. A zero t leaves the bound unset.
+func WithMaxTime(t time.Time) BundleOption {
+	return func(p *SendBundleParams) {
+		p.MaxTimestamp = unixOrZero(t)
+	}
+}
+
+func unixOrZero(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.Unix()
+}
+
+// WithBundlePreferences sets the bundle's submission preferences, see
+// BundlePreferences.
+func WithBundlePreferences(preferences BundlePreferences) BundleOption {
+	return func(p *SendBundleParams) {
+		p.Preferences = &preferences
+	}
+}
+
+// CallBundleOption configures a single CallBundle simulation.
+type CallBundleOption func(*CallBundleParams)
+
+// WithLogs asks the relay to return the logs emitted by every simulated
+// transaction in TxResult.Logs. Relays without support ignore it.
+func WithLogs() CallBundleOption {
+	return func(p *CallBundleParams) {
+		p.IncludeLogs = true
+	}
+}
+
+// WithCoinbase simulates the bundle with coinbase as the block's fee
+// recipient, keeping coinbase diffs deterministic. Relays without support
+// ignore it.
+func WithCoinbase(coinbase string) CallBundleOption {
+	return func(p *CallBundleParams) {
+		p.Coinbase = coinbase
+	}
+}
+
+// WithGasLimit simulates the bundle in a block with the given gas limit
+// instead of the parent block's. A limit of 0 keeps the relay's default and
+// negative limits are rejected. Relays without support ignore it.
+func WithGasLimit(gasLimit int64) CallBundleOption {
+	return func(p *CallBundleParams) {
+		p.GasLimit = gasLimit
+	}
+}
+
+// WithAccessList asks the relay to generate an EIP-2930 access list for
+// every simulated transaction, returned in TxResult.AccessList, for use in
+// the real transactions. The Flashbots relay does not generate access
+// lists; only relays and builders that document the generateAccessList
+// flag do, and others ignore it.
+func WithAccessList() CallBundleOption {
+	return func(p *CallBundleParams) {
+		p.GenerateAccessList = true
+	}
+}
+
+// MevBundleOption configures a single SendMevBundle call.
+type MevBundleOption func(*MevSendBundleParams)
+
+// WithMevPrivacyHints sets the MEV-Share privacy hints of the bundle,
+// replacing the client's defaults. Passing no hints sends an empty list.
+func WithMevPrivacyHints(hints ...string) MevBundleOption {
+	return func(p *MevSendBundleParams) {
+		if p.Privacy == nil {
+			p.Privacy = new(MevPrivacy)
+		}
+		p.Privacy.Hints = append([]string{}, hints...)
+	}
+}
+
+// WithMevBuilders restricts which builders receive the bundle, named as
+// MEV-Share lists them, see WithOnlyBuilders.
+func WithMevBuilders(builders ...string) MevBundleOption {
+	return func(p *MevSendBundleParams) {
+		if p.Privacy == nil {
+			p.Privacy = new(MevPrivacy)
+		}
+		p.Privacy.OnlyBuilders = append([]string(nil), builders...)
+	}
+}
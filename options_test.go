@@ -0,0 +1,82 @@
+package flashbot
+
+import (
+	"bytes"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDefaultTransport(t *testing.T) {
+	proxy := http.ProxyURL(&url.URL{Scheme: "http", Host: "proxy.internal:3128"})
+
+	tests := []struct {
+		name        string
+		opts        []Option
+		wantPerHost int
+		wantTimeout time.Duration
+	}{
+		{name: "default", wantPerHost: defaultMaxIdleConnsPerHost, wantTimeout: defaultTimeout},
+		{name: "timeout", opts: []Option{WithTimeout(time.Second)}, wantPerHost: defaultMaxIdleConnsPerHost, wantTimeout: time.Second},
+		{name: "proxy", opts: []Option{WithProxy(proxy)}, wantPerHost: defaultMaxIdleConnsPerHost, wantTimeout: defaultTimeout},
+		{name: "pool", opts: []Option{WithConnectionPool(8, 4, time.Minute)}, wantPerHost: 4, wantTimeout: defaultTimeout},
+		{
+			name:        "custom client without transport",
+			opts:        []Option{WithHTTPClient(&http.Client{}), WithProxy(proxy)},
+			wantPerHost: defaultMaxIdleConnsPerHost,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newTestClient(t, "", tt.opts...).httpClient()
+
+			transport, ok := client.Transport.(*http.Transport)
+			if !ok {
+				t.Fatalf("transport is %T, want *http.Transport", client.Transport)
+			}
+			if transport.MaxIdleConnsPerHost != tt.wantPerHost {
+				t.Errorf("MaxIdleConnsPerHost = %d, want %d", transport.MaxIdleConnsPerHost, tt.wantPerHost)
+			}
+			if client.Timeout != tt.wantTimeout {
+				t.Errorf("Timeout = %s, want %s", client.Timeout, tt.wantTimeout)
+			}
+		})
+	}
+}
+
+func TestInsecureSkipVerifyWarning(t *testing.T) {
+	tests := []struct {
+		name string
+		opts func(logger *log.Logger) []Option
+		want int
+	}{
+		{
+			name: "before WithLogger",
+			opts: func(logger *log.Logger) []Option { return []Option{WithInsecureSkipVerify(), WithLogger(logger)} },
+			want: 1,
+		},
+		{
+			name: "after WithLogger",
+			opts: func(logger *log.Logger) []Option { return []Option{WithLogger(logger), WithInsecureSkipVerify()} },
+			want: 1,
+		},
+		{
+			name: "verifying",
+			opts: func(logger *log.Logger) []Option { return []Option{WithLogger(logger)} },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			newTestClient(t, "https://relay.example", tt.opts(log.New(&buf, "", 0))...)
+			if n := strings.Count(buf.String(), "TLS certificate verification is disabled"); n != tt.want {
+				t.Errorf("logger got %d warnings, want %d:\n%s", n, tt.want, buf.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,68 @@
+package flashbot
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// paramShape is how a relay method expects its JSON-RPC params.
+type paramShape int
+
+const (
+	// paramsValues passes the arguments as the params array, e.g.
+	// ["0x1f9...", "0x7e5..."].
+	paramsValues paramShape = iota
+	// paramsObject wraps a single argument object in a one element array,
+	// e.g. [{"txs": [...], "blockNumber": "0x10d4f2a"}].
+	paramsObject
+	// paramsBlock passes a single block number as a hex quantity, e.g.
+	// ["0x10d4f2a"].
+	paramsBlock
+)
+
+// methodParamShapes lists the param shape of every modelled method.
+// Methods missing here, such as probed ones, take plain values.
+var methodParamShapes = map[string]paramShape{
+	MethodSendBundle:                    paramsObject,
+	MethodCancelBundle:                  paramsObject,
+	MethodMevSendBundle:                 paramsObject,
+	MethodCallBundle:                    paramsObject,
+	MethodSendPrivateTransaction:        paramsObject,
+	MethodCancelPrivateTransaction:      paramsObject,
+	MethodEstimateGasBundle:             paramsObject,
+	MethodGetBundleStats:                paramsObject,
+	MethodGetBundleStatsV2:              paramsObject,
+	MethodGetUserStats:                  paramsBlock,
+	MethodSetFeeRefundRecipient:         paramsValues,
+	MethodGetFeeRefundTotalsByRecipient: paramsValues,
+}
+
+// buildParams returns the params array of method for the given arguments.
+func buildParams(method string, params []interface{}) ([]interface{}, error) {
+	if methodParamShapes[method] == paramsObject && len(params) > 1 {
+		return nil, fmt.Errorf("%s takes a single params object, got %d arguments", method, len(params))
+	}
+	if params == nil {
+		return []interface{}{}, nil
+	}
+
+	if methodParamShapes[method] == paramsBlock {
+		if len(params) != 1 {
+			return nil, fmt.Errorf("%s takes a single block number, got %d arguments", method, len(params))
+		}
+		switch block := params[0].(type) {
+		case uint64:
+			return []interface{}{HextoBlockNumber(block)}, nil
+		case string:
+			if _, err := hexutil.DecodeUint64(block); err != nil {
+				return nil, fmt.Errorf("%s: invalid block number %q", method, block)
+			}
+			return params, nil
+		default:
+			return nil, fmt.Errorf("%s: block number must be uint64 or a hex string, got %T", method, block)
+		}
+	}
+
+	return params, nil
+}
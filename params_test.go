@@ -0,0 +1,151 @@
+package flashbot
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func TestBuildParams(t *testing.T) {
+	tests := []struct {
+		name    string
+		method  string
+		params  []interface{}
+		want    string
+		wantErr bool
+	}{
+		{name: "no params", method: MethodSendBundle, want: `[]`},
+		{name: "object", method: MethodSendBundle, params: []interface{}{map[string]string{"blockNumber": "0x1"}}, want: `[{"blockNumber":"0x1"}]`},
+		{name: "two objects", method: MethodCallBundle, params: []interface{}{1, 2}, wantErr: true},
+		{name: "block number", method: MethodGetUserStats, params: []interface{}{uint64(17000000)}, want: `["0x1036640"]`},
+		{name: "hex block number", method: MethodGetUserStats, params: []interface{}{"0x1036640"}, want: `["0x1036640"]`},
+		{name: "decimal block string", method: MethodGetUserStats, params: []interface{}{"17000000"}, wantErr: true},
+		{name: "int block number", method: MethodGetUserStats, params: []interface{}{17000000}, wantErr: true},
+		{name: "two block numbers", method: MethodGetUserStats, params: []interface{}{uint64(1), uint64(2)}, wantErr: true},
+		{name: "values", method: MethodSetFeeRefundRecipient, params: []interface{}{"0xa", "0xb"}, want: `["0xa","0xb"]`},
+		{name: "unknown method", method: "flashbots_unknown", params: []interface{}{1, "a"}, want: `[1,"a"]`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := buildParams(tt.method, tt.params)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("buildParams = %v, want error", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			encoded, err := json.Marshal(got)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(encoded) != tt.want {
+				t.Errorf("params = %s, want %s", encoded, tt.want)
+			}
+		})
+	}
+}
+
+func TestMethodParamShapesCoverSupportedMethods(t *testing.T) {
+	for _, method := range SupportedMethods() {
+		if _, ok := methodParamShapes[method]; !ok {
+			t.Errorf("%s has no param shape", method)
+		}
+	}
+}
+
+// TestWireParams checks the params every method puts on the wire.
+func TestWireParams(t *testing.T) {
+	tx := signedTestTx(t, 0)
+	recipient := common.HexToAddress("0x000000000000000000000000000000000000bEEF").Hex()
+	bundleHash := "0x" + strings.Repeat("ab", 32)
+
+	tests := []struct {
+		method string
+		call   func(f *FlashbotLaunch)
+		want   string
+	}{
+		{
+			method: MethodSendBundle,
+			call: func(f *FlashbotLaunch) {
+				f.SendBundle([]string{tx}, 17000000, WithReplacementUUID(testUUID))
+			},
+			want: `[{"txs":["` + tx + `"],"blockNumber":"0x1036640","replacementUuid":"` + testUUID + `"}]`,
+		},
+		{
+			method: MethodCallBundle,
+			call: func(f *FlashbotLaunch) {
+				f.CallBundle([]string{tx}, 17000000)
+			},
+			want: `[{"txs":["` + tx + `"],"blockNumber":"0x1036640","stateBlockNumber":"latest","timestamp":1615920932}]`,
+		},
+		{
+			method: MethodGetUserStats,
+			call: func(f *FlashbotLaunch) {
+				f.GetUserStats(17000000)
+			},
+			want: `["0x1036640"]`,
+		},
+		{
+			method: MethodGetBundleStats,
+			call: func(f *FlashbotLaunch) {
+				f.GetBundleStats(bundleHash, 17000000)
+			},
+			want: `[{"bundleHash":"` + bundleHash + `","blockNumber":"0x1036640"}]`,
+		},
+		{
+			method: MethodCancelBundle,
+			call: func(f *FlashbotLaunch) {
+				f.CancelBundle(testUUID)
+			},
+			want: `[{"replacementUuid":"` + testUUID + `"}]`,
+		},
+		{
+			method: MethodSetFeeRefundRecipient,
+			call: func(f *FlashbotLaunch) {
+				f.SetFeeRefundRecipient(recipient)
+			},
+			want: `["` + testSignerAddress(t) + `","` + recipient + `"]`,
+		},
+		{
+			method: MethodGetFeeRefundTotalsByRecipient,
+			call: func(f *FlashbotLaunch) {
+				f.GetFeeRefundTotalsByRecipient(recipient)
+			},
+			want: `["` + recipient + `"]`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.method, func(t *testing.T) {
+			relay := newTestRelay(t, `{"jsonrpc":"2.0","id":1,"result":null}`)
+			f := newTestClient(t, relay.URL)
+
+			tt.call(f)
+
+			var envelope struct {
+				Method string `json:"method"`
+			}
+			if err := json.Unmarshal(relay.last(t).Body, &envelope); err != nil {
+				t.Fatal(err)
+			}
+			if envelope.Method != tt.method {
+				t.Errorf("method = %s, want %s", envelope.Method, tt.method)
+			}
+			if got := relay.lastParams(t); got != tt.want {
+				t.Errorf("params =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+// testSignerAddress is the address of the test key.
+func testSignerAddress(t *testing.T) string {
+	t.Helper()
+	return newTestClient(t, "").requestSigner().Address().Hex()
+}
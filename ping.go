@@ -0,0 +1,72 @@
+package flashbot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+)
+
+var (
+	// ErrRelayUnreachable is returned by Ping when the request to the relay
+	// failed without a response.
+	ErrRelayUnreachable = errors.New("relay unreachable")
+	// ErrSignatureRejected is returned by Ping when the relay refused the
+	// X-Flashbots-Signature of the request.
+	ErrSignatureRejected = errors.New("relay rejected the request signature")
+	// ErrSignerUnknown is returned by Ping when the relay accepted the
+	// request but has no stats for the signing address.
+	ErrSignerUnknown = errors.New("relay does not know the signer")
+)
+
+// Ping checks that the relay is reachable, accepts the client's signature
+// and knows its signer, by requesting the signer's user stats for the
+// latest block. It is meant as a startup health check and requires
+// NodeRpc for the block number. Errors other than the relay not answering,
+// such as a *RateLimitedError or a *CanceledError, are returned wrapped.
+func (f *FlashbotLaunch) Ping(ctx context.Context) error {
+	block, err := f.latestBlockNumber(ctx)
+	if err != nil {
+		return fmt.Errorf("ping: latest block: %w", err)
+	}
+
+	signer := f.requestSigner()
+	resp, err := f.requestRPCAs(ctx, signer, MethodGetUserStats, block)
+	if err != nil {
+		if isTransportFailure(err) {
+			return fmt.Errorf("%w: %w", ErrRelayUnreachable, err)
+		}
+		return fmt.Errorf("ping: %w", err)
+	}
+	userStatsResp := new(UserStatsResponse)
+	if err := f.decodeResponse(MethodGetUserStats, resp, userStatsResp); err != nil {
+		return fmt.Errorf("ping: invalid relay response: %w", err)
+	}
+
+	if rpcErr := userStatsResp.Error; rpcErr != nil {
+		if strings.Contains(strings.ToLower(rpcErr.Message), "signature") {
+			return fmt.Errorf("%w: %v", ErrSignatureRejected, rpcErr)
+		}
+		return fmt.Errorf("ping: %w", rpcErr)
+	}
+	if userStatsResp.Result == nil {
+		return fmt.Errorf("%w: %s", ErrSignerUnknown, signer.Address().Hex())
+	}
+
+	return nil
+}
+
+// isTransportFailure reports whether err means the relay never answered,
+// as opposed to answering with an error or the request being canceled.
+func isTransportFailure(err error) bool {
+	var canceled *CanceledError
+	if errors.As(err, &canceled) {
+		return false
+	}
+
+	var urlErr *url.Error
+	var netErr net.Error
+	return errors.As(err, &urlErr) || errors.As(err, &netErr)
+}
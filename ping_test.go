@@ -0,0 +1,96 @@
+package flashbot
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestPingErrors(t *testing.T) {
+	node := newTestRelay(t, `{"jsonrpc":"2.0","id":1,"result":"0x1036640"}`)
+	received := make(chan struct{}, 1)
+
+	tests := []struct {
+		name            string
+		handler         http.HandlerFunc
+		wantUnreachable bool
+		wantRateLimited bool
+		want            error
+	}{
+		{
+			name:            "connection refused",
+			wantUnreachable: true,
+		},
+		{
+			name: "rate limited",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				io.Copy(io.Discard, r.Body)
+				w.WriteHeader(http.StatusTooManyRequests)
+			},
+			wantRateLimited: true,
+		},
+		{
+			name: "truncated response",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				io.WriteString(w, partialBody)
+			},
+			want: ErrTruncatedResponse,
+		},
+		{
+			name: "signature rejected",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				io.WriteString(w, `{"jsonrpc":"2.0","id":1,"error":{"code":-32600,"message":"invalid signature"}}`)
+			},
+			want: ErrSignatureRejected,
+		},
+		{
+			name: "canceled",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				io.Copy(io.Discard, r.Body)
+				received <- struct{}{}
+				<-r.Context().Done()
+			},
+			want: context.Canceled,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			relay := httptest.NewServer(tt.handler)
+			if tt.handler == nil {
+				relay.Close()
+			} else {
+				defer relay.Close()
+			}
+			f := newTestClient(t, relay.URL, WithNodeRpc(node.URL))
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			go func() {
+				select {
+				case <-received:
+					cancel()
+				case <-ctx.Done():
+				}
+			}()
+
+			err := f.Ping(ctx)
+			if err == nil {
+				t.Fatal("Ping succeeded")
+			}
+			if errors.Is(err, ErrRelayUnreachable) != tt.wantUnreachable {
+				t.Errorf("err = %v, want ErrRelayUnreachable %t", err, tt.wantUnreachable)
+			}
+			var rateErr *RateLimitedError
+			if errors.As(err, &rateErr) != tt.wantRateLimited {
+				t.Errorf("err = %v, want *RateLimitedError %t", err, tt.wantRateLimited)
+			}
+			if tt.want != nil && !errors.Is(err, tt.want) {
+				t.Errorf("err = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
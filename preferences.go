@@ -0,0 +1,77 @@
+package flashbot
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// PreferenceAllowBackruns lets the relay share the bundle with searchers
+// for backrunning.
+const PreferenceAllowBackruns = "allowBackruns"
+
+var (
+	bundlePreferencesMu sync.RWMutex
+	bundlePreferences   = map[string]bool{
+		PreferenceAllowBackruns: true,
+	}
+)
+
+// RegisterBundlePreference adds a signal name to those BundlePreferences
+// accepts, for relays taking signals this package does not know.
+func RegisterBundlePreference(name string) {
+	bundlePreferencesMu.Lock()
+	defer bundlePreferencesMu.Unlock()
+
+	bundlePreferences[name] = true
+}
+
+func knownBundlePreference(name string) bool {
+	bundlePreferencesMu.RLock()
+	defer bundlePreferencesMu.RUnlock()
+
+	return bundlePreferences[name]
+}
+
+// BundlePreferences is the preferences object of eth_sendBundle, selecting
+// how the relay handles the bundle.
+type BundlePreferences struct {
+	// Builders restricts which builders receive the bundle, named as
+	// MEV-Share lists them. Unknown names are logged, see
+	// RegisterMevShareBuilder.
+	Builders []string
+	// Signals are boolean preferences by name, such as
+	// PreferenceAllowBackruns. Unknown names are rejected, see
+	// RegisterBundlePreference.
+	Signals map[string]bool
+}
+
+// MarshalJSON sends the signals next to builders in one object.
+func (p BundlePreferences) MarshalJSON() ([]byte, error) {
+	preferences := make(map[string]interface{}, len(p.Signals)+1)
+	for name, value := range p.Signals {
+		preferences[name] = value
+	}
+	if len(p.Builders) > 0 {
+		preferences["builders"] = p.Builders
+	}
+
+	return json.Marshal(preferences)
+}
+
+// validate rejects unknown signal names, naming them in order.
+func (p BundlePreferences) validate() error {
+	var unknown []string
+	for name := range p.Signals {
+		if !knownBundlePreference(name) {
+			unknown = append(unknown, name)
+		}
+	}
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return fmt.Errorf("unknown bundle preferences %q", unknown)
+	}
+
+	return nil
+}
@@ -0,0 +1,140 @@
+package flashbot
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// MarshalJSON merges Privacy and Validity into the preferences object.
+func (p SendPrivateTx) MarshalJSON() ([]byte, error) {
+	type plain SendPrivateTx
+
+	var preferences map[string]interface{}
+	if p.Preferences != nil || p.Privacy != nil || p.Validity != nil {
+		preferences = make(map[string]interface{}, len(p.Preferences)+2)
+		for name, value := range p.Preferences {
+			preferences[name] = value
+		}
+		if p.Privacy != nil {
+			preferences["privacy"] = p.Privacy
+		}
+		if p.Validity != nil {
+			preferences["validity"] = p.Validity
+		}
+	}
+
+	return json.Marshal(struct {
+		plain
+		Preferences map[string]interface{} `json:"preferences,omitempty"`
+	}{plain(p), preferences})
+}
+
+// MarshalJSON leaves out unset hints but keeps an explicitly empty list,
+// which asks MEV-Share to share nothing.
+func (p PrivacyPreferences) MarshalJSON() ([]byte, error) {
+	type plain PrivacyPreferences
+	if p.Hints != nil {
+		return json.Marshal(plain(p))
+	}
+
+	return json.Marshal(struct {
+		OnlyBuilders []string `json:"builders,omitempty"`
+	}{p.OnlyBuilders})
+}
+
+// applyRefundPercent adds a refund of percent percent to the sender of the
+// transaction.
+func (p *SendPrivateTx) applyRefundPercent(percent int) error {
+	if percent < 0 || percent > 100 {
+		return fmt.Errorf("refund percent %d is not between 0 and 100", percent)
+	}
+
+	tx, err := decodeTransaction(p.Transaction)
+	if err != nil {
+		return err
+	}
+	sender, err := types.Sender(types.LatestSignerForChainID(tx.ChainId()), tx)
+	if err != nil {
+		return err
+	}
+
+	if p.Validity == nil {
+		p.Validity = new(Validity)
+	}
+	p.Validity.Refund = append(p.Validity.Refund, Refund{Address: sender, Percent: percent})
+	return nil
+}
+
+// replacementKey identifies the transaction slot a private transaction
+// replaces: its sender and nonce.
+type replacementKey struct {
+	sender common.Address
+	nonce  uint64
+}
+
+// replacementTracker remembers the last accepted replacement nonce of
+// every transaction slot, see WithReplacementTracking.
+type replacementTracker struct {
+	mu   sync.Mutex
+	last map[replacementKey]uint64
+}
+
+// check returns the slot of the raw transaction, failing when
+// replacementNonce does not exceed the last one accepted for it.
+func (t *replacementTracker) check(rawTx string, replacementNonce uint64) (replacementKey, error) {
+	tx, err := decodeTransaction(rawTx)
+	if err != nil {
+		return replacementKey{}, err
+	}
+	sender, err := types.Sender(types.LatestSignerForChainID(tx.ChainId()), tx)
+	if err != nil {
+		return replacementKey{}, err
+	}
+	key := replacementKey{sender: sender, nonce: tx.Nonce()}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if last, ok := t.last[key]; ok && replacementNonce <= last {
+		return key, fmt.Errorf("replacement nonce %d for %s nonce %d does not exceed previous %d", replacementNonce, sender.Hex(), key.nonce, last)
+	}
+
+	return key, nil
+}
+
+func (t *replacementTracker) record(key replacementKey, replacementNonce uint64) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.last == nil {
+		t.last = make(map[replacementKey]uint64)
+	}
+	if replacementNonce > t.last[key] {
+		t.last[key] = replacementNonce
+	}
+}
+
+// applyPrivacyDefaults returns privacy with the client's default hints
+// filled in when it sets none, logging builder names MEV-Share is not
+// known to accept.
+func (f *FlashbotLaunch) applyPrivacyDefaults(privacy *PrivacyPreferences) *PrivacyPreferences {
+	if len(f.defaultHints) > 0 && (privacy == nil || privacy.Hints == nil) {
+		if privacy == nil {
+			privacy = new(PrivacyPreferences)
+		}
+		privacy.Hints = f.defaultHints
+	}
+	if privacy != nil {
+		for _, name := range privacy.OnlyBuilders {
+			if !knownMevShareBuilder(name) {
+				f.logf("flashbot: builder %q is not a known MEV-Share builder name", name)
+			}
+		}
+	}
+
+	return privacy
+}
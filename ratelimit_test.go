@@ -0,0 +1,55 @@
+package flashbot
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// newRateLimitedRelay starts a relay answering every request with 429 and
+// the given Retry-After header.
+func newRateLimitedRelay(t *testing.T, retryAfter string) *httptest.Server {
+	t.Helper()
+
+	relay := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.Copy(io.Discard, r.Body)
+		w.Header().Set("Retry-After", retryAfter)
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	t.Cleanup(relay.Close)
+
+	return relay
+}
+
+func TestRateLimitedFailover(t *testing.T) {
+	tx := signedTestTx(t, 0)
+
+	t.Run("fallback answers", func(t *testing.T) {
+		fallback := newTestRelay(t, `{"jsonrpc":"2.0","id":1,"result":{"bundleHash":"0x01"}}`)
+		f := newTestClient(t, newRateLimitedRelay(t, "1").URL, WithFallbackRelays(fallback.URL))
+
+		resp, err := f.SendBundle([]string{tx}, 17000000)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := resp.Err(); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	t.Run("every relay rate limited", func(t *testing.T) {
+		f := newTestClient(t, newRateLimitedRelay(t, "1").URL, WithFallbackRelays(newRateLimitedRelay(t, "2").URL))
+
+		_, err := f.SendBundle([]string{tx}, 17000000)
+		var rateErr *RateLimitedError
+		if !errors.As(err, &rateErr) {
+			t.Fatalf("err = %v, want *RateLimitedError", err)
+		}
+		if rateErr.RetryAfter != 2*time.Second {
+			t.Errorf("RetryAfter = %s, want the last relay's 2s", rateErr.RetryAfter)
+		}
+	})
+}
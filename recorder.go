@@ -0,0 +1,67 @@
+package flashbot
+
+import (
+	"sync"
+	"time"
+)
+
+// BundleSubmission is the audit record of one SendBundle call.
+type BundleSubmission struct {
+	UUID        string
+	TxHashes    []string
+	BlockNumber uint64
+	Time        time.Time
+	// BundleHash is the hash reported by the relay, or the locally computed
+	// one when the relay did not return any.
+	BundleHash string
+	Response   *SendBundleResponse
+	Err        error
+}
+
+// SubmissionRecorder receives a BundleSubmission for every SendBundle call,
+// see WithSubmissionRecorder.
+type SubmissionRecorder interface {
+	RecordSubmission(BundleSubmission)
+}
+
+// MemoryRecorder is a SubmissionRecorder keeping all submissions in memory.
+// The zero value is ready to use.
+type MemoryRecorder struct {
+	mu          sync.Mutex
+	submissions []BundleSubmission
+}
+
+func (m *MemoryRecorder) RecordSubmission(submission BundleSubmission) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.submissions = append(m.submissions, submission)
+}
+
+// Submissions returns a copy of the recorded submissions, oldest first.
+func (m *MemoryRecorder) Submissions() []BundleSubmission {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return append([]BundleSubmission(nil), m.submissions...)
+}
+
+func (f *FlashbotLaunch) recordSubmission(transactions []string, blockNumber uint64, uuid string, resp *SendBundleResponse, err error) {
+	submission := BundleSubmission{
+		UUID:        uuid,
+		BlockNumber: blockNumber,
+		Time:        time.Now(),
+		Response:    resp,
+		Err:         err,
+	}
+
+	// Malformed transactions are recorded without hashes.
+	submission.TxHashes, _ = TxHashes(transactions)
+	if resp != nil && resp.Result != nil {
+		submission.BundleHash = resp.Result.BundleHash
+	} else {
+		submission.BundleHash, _ = ComputeBundleHash(transactions)
+	}
+
+	f.recorder.RecordSubmission(submission)
+}
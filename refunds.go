@@ -0,0 +1,96 @@
+package flashbot
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+const (
+	// `flashbots_setFeeRefundRecipient` delegates the fee refunds earned by
+	// the signing address to another recipient.
+	MethodSetFeeRefundRecipient = "flashbots_setFeeRefundRecipient"
+
+	// `flashbots_getFeeRefundTotalsByRecipient` returns the pending and
+	// received fee refunds of a recipient.
+	MethodGetFeeRefundTotalsByRecipient = "flashbots_getFeeRefundTotalsByRecipient"
+)
+
+// ############
+// fee refunds
+// ############
+type SetFeeRefundRecipientResponse struct {
+	ID      uint                 `json:"id"`
+	Version string               `json:"jsonrpc"`
+	Result  *FeeRefundDelegation `json:"result"`
+	Error   *errorResult         `json:"error"`
+}
+
+type FeeRefundDelegation struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+type FeeRefundTotalsResponse struct {
+	ID      uint             `json:"id"`
+	Version string           `json:"jsonrpc"`
+	Result  *FeeRefundTotals `json:"result"`
+	Error   *errorResult     `json:"error"`
+}
+
+type FeeRefundTotals struct {
+	Pending  string `json:"pending"`
+	Received string `json:"received"`
+}
+
+// SetFeeRefundRecipient sends the fee refunds of the signing address to
+// recipient from now on. The request has to be signed by the delegating
+// address, so the same signer provides the delegating address and the
+// signature.
+func (f *FlashbotLaunch) SetFeeRefundRecipient(recipient string) (*SetFeeRefundRecipientResponse, error) {
+	if !common.IsHexAddress(recipient) {
+		return nil, fmt.Errorf("invalid recipient address %q", recipient)
+	}
+
+	signer := f.requestSigner()
+	resp, err := f.requestRPCAs(context.Background(), signer, MethodSetFeeRefundRecipient, signer.Address().Hex(), recipient)
+	if err != nil {
+		return nil, err
+	}
+	setResp := new(SetFeeRefundRecipientResponse)
+	if err := f.decodeResponse(MethodSetFeeRefundRecipient, resp, setResp); err != nil {
+		return nil, err
+	}
+
+	return setResp, nil
+}
+
+// GetFeeRefundTotalsByRecipient returns the pending and received fee
+// refunds of recipient, in wei.
+func (f *FlashbotLaunch) GetFeeRefundTotalsByRecipient(recipient string) (*FeeRefundTotalsResponse, error) {
+	if !common.IsHexAddress(recipient) {
+		return nil, fmt.Errorf("invalid recipient address %q", recipient)
+	}
+
+	resp, err := f.requestRPC(MethodGetFeeRefundTotalsByRecipient, recipient)
+	if err != nil {
+		return nil, err
+	}
+	totalsResp := new(FeeRefundTotalsResponse)
+	if err := f.decodeResponse(MethodGetFeeRefundTotalsByRecipient, resp, totalsResp); err != nil {
+		return nil, err
+	}
+
+	return totalsResp, nil
+}
+
+// Err returns the error of a failed flashbots_setFeeRefundRecipient call, or nil.
+func (r *SetFeeRefundRecipientResponse) Err() error {
+	return checkRPCError(r.Error, r.Result)
+}
+
+// Err returns the error of a failed flashbots_getFeeRefundTotalsByRecipient call, or nil.
+func (r *FeeRefundTotalsResponse) Err() error {
+	return checkRPCError(r.Error, r.Result)
+}
@@ -0,0 +1,180 @@
+package flashbot
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// resubmitPollInterval is how often AutoResubmit checks for a new block.
+const resubmitPollInterval = time.Second
+
+// SubmitEventKind is what happened to an automatically resubmitted bundle.
+type SubmitEventKind int
+
+const (
+	// EventSubmitted means the bundle was sent for BlockNumber.
+	EventSubmitted SubmitEventKind = iota
+	// EventSimulationFailed means a transaction failed when simulated for
+	// BlockNumber, so the bundle was not sent for it.
+	EventSimulationFailed
+	// EventIncluded means the bundle landed in BlockNumber. It is the last
+	// event.
+	EventIncluded
+	// EventFailed means a request failed; Err says why. Resubmission goes
+	// on with the next block.
+	EventFailed
+)
+
+func (k SubmitEventKind) String() string {
+	switch k {
+	case EventSubmitted:
+		return "submitted"
+	case EventSimulationFailed:
+		return "simulation failed"
+	case EventIncluded:
+		return "included"
+	default:
+		return "failed"
+	}
+}
+
+// SubmitEvent reports one step of AutoResubmit.
+type SubmitEvent struct {
+	Kind        SubmitEventKind
+	BlockNumber uint64
+	// Response is set for EventSubmitted.
+	Response *SendBundleResponse
+	// Simulation is set for EventSimulationFailed.
+	Simulation *CallBundleResponse
+	Err        error
+}
+
+// AutoResubmit sends the bundle for every new block until it is included,
+// deadlineBlock has been targeted or ctx is done, reporting each step on
+// the returned channel, which is closed at the end. Before every block the
+// bundle is simulated and only sent when all transactions succeed.
+// Inclusion is detected through the receipt of the bundle's last
+// transaction. It requires NodeRpc.
+func (f *FlashbotLaunch) AutoResubmit(ctx context.Context, transactions []string, deadlineBlock uint64) (<-chan SubmitEvent, error) {
+	if len(transactions) < 1 {
+		return nil, errorTransaction
+	}
+	hashes, err := TxHashes(transactions)
+	if err != nil {
+		return nil, err
+	}
+
+	latest, err := f.LatestBlockNumber()
+	if err != nil {
+		return nil, err
+	}
+	if deadlineBlock <= latest {
+		return nil, fmt.Errorf("deadline block %d is in the past, next block is %d", deadlineBlock, latest+1)
+	}
+
+	events := make(chan SubmitEvent, 16)
+	go func() {
+		defer close(events)
+		f.autoResubmit(ctx, transactions, hashes[len(hashes)-1], latest, deadlineBlock, events)
+	}()
+
+	return events, nil
+}
+
+func (f *FlashbotLaunch) autoResubmit(ctx context.Context, transactions []string, lastHash string, latest, deadlineBlock uint64, events chan<- SubmitEvent) {
+	emit := func(event SubmitEvent) bool {
+		select {
+		case events <- event:
+			return true
+		case <-ctx.Done():
+			return false
+		}
+	}
+
+	ticker := time.NewTicker(resubmitPollInterval)
+	defer ticker.Stop()
+
+	for target := latest + 1; target <= deadlineBlock; target = latest + 1 {
+		if !emit(f.resubmitBlock(ctx, transactions, target)) {
+			return
+		}
+
+		// Wait for the target block to be built, then look for the bundle.
+		for latest < target {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+
+			block, err := f.latestBlockNumber(ctx)
+			if err != nil {
+				if !emit(SubmitEvent{Kind: EventFailed, BlockNumber: target, Err: err}) {
+					return
+				}
+				continue
+			}
+			latest = block
+		}
+
+		included, ok, err := f.receiptBlock(ctx, lastHash)
+		switch {
+		case err != nil:
+			if !emit(SubmitEvent{Kind: EventFailed, BlockNumber: target, Err: err}) {
+				return
+			}
+		case ok:
+			emit(SubmitEvent{Kind: EventIncluded, BlockNumber: included})
+			return
+		}
+	}
+}
+
+// resubmitBlock simulates the bundle for block and sends it if the
+// simulation succeeded.
+func (f *FlashbotLaunch) resubmitBlock(ctx context.Context, transactions []string, block uint64) SubmitEvent {
+	sim, err := f.CallBundleContext(ctx, transactions, block)
+	if err != nil {
+		return SubmitEvent{Kind: EventFailed, BlockNumber: block, Err: err}
+	}
+	if sim.SimulationFailed() {
+		return SubmitEvent{Kind: EventSimulationFailed, BlockNumber: block, Simulation: sim, Err: errors.New("bundle transaction failed in simulation")}
+	}
+
+	resp, err := f.SendBundleContext(ctx, transactions, block)
+	if err == nil {
+		err = resp.Err()
+	}
+	if err != nil {
+		return SubmitEvent{Kind: EventFailed, BlockNumber: block, Response: resp, Err: err}
+	}
+
+	return SubmitEvent{Kind: EventSubmitted, BlockNumber: block, Response: resp}
+}
+
+// receiptBlock returns the block the transaction was mined in, with ok
+// false while it is not mined.
+func (f *FlashbotLaunch) receiptBlock(ctx context.Context, txHash string) (uint64, bool, error) {
+	result, err := f.callNodeContext(ctx, "eth_getTransactionReceipt", txHash)
+	if err != nil {
+		return 0, false, err
+	}
+	if len(result) == 0 || bytes.Equal(result, []byte("null")) {
+		return 0, false, nil
+	}
+
+	var receipt struct {
+		BlockNumber hexutil.Uint64 `json:"blockNumber"`
+	}
+	if err := json.Unmarshal(result, &receipt); err != nil {
+		return 0, false, err
+	}
+
+	return uint64(receipt.BlockNumber), true, nil
+}
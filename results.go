@@ -0,0 +1,353 @@
+package flashbot
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"reflect"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// UnmarshalJSON decodes the modelled fields and keeps every other field in
+// Extra. A bare, non-object result is kept under the "result" key.
+func (b *BundleResult) UnmarshalJSON(data []byte) error {
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] != '{' {
+		*b = BundleResult{extra: map[string]json.RawMessage{"result": append(json.RawMessage(nil), trimmed...)}}
+		return nil
+	}
+
+	type plain BundleResult
+	if err := json.Unmarshal(data, (*plain)(b)); err != nil {
+		return err
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+	delete(fields, "bundleHash")
+	delete(fields, "blockNumber")
+	if len(fields) > 0 {
+		b.extra = fields
+	}
+
+	return nil
+}
+
+// Extra returns the raw result fields BundleResult does not model, such as
+// the opaque bundle id returned by relays using the sbundle model.
+func (b *BundleResult) Extra() map[string]json.RawMessage {
+	return b.extra
+}
+
+// ErrBlockMismatch is returned by SendBundleResponse.CheckBlock when the
+// relay accepted a bundle for another block than requested.
+var ErrBlockMismatch = errors.New("accepted block does not match requested block")
+
+// checkRPCError returns the JSON-RPC error of a response, or an error when
+// the response carries neither an error nor a result.
+func checkRPCError(rpcErr *errorResult, result interface{}) error {
+	if rpcErr != nil {
+		return rpcErr
+	}
+
+	v := reflect.ValueOf(result)
+	switch v.Kind() {
+	case reflect.Invalid:
+		return errorEmptyResult
+	case reflect.Ptr, reflect.Map, reflect.Slice, reflect.Interface:
+		if v.IsNil() {
+			return errorEmptyResult
+		}
+	case reflect.String:
+		if v.Len() == 0 {
+			return errorEmptyResult
+		}
+	}
+
+	return nil
+}
+
+// Err returns the error of a failed eth_sendBundle call, or nil.
+func (r *SendBundleResponse) Err() error {
+	return checkRPCError(r.Error, r.Result)
+}
+
+// Err returns the error of a failed eth_callBundle call, or nil. Reverted
+// transactions of a successful simulation are not errors.
+func (r *CallBundleResponse) Err() error {
+	return checkRPCError(r.Error, r.Result)
+}
+
+// SimulationFailed reports whether the relay simulated the bundle and at
+// least one transaction failed, e.g. reverted. Its TxResult.Error and
+// ErrorKind say why. Relay rejections, such as a bad request or failed
+// authentication, are returned as errors by CallBundle instead.
+func (r *CallBundleResponse) SimulationFailed() bool {
+	if r.Result == nil {
+		return false
+	}
+	for _, result := range r.Result.Results {
+		if result.Error != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// Err returns the error of a failed eth_sendPrivateTransaction call, or nil.
+func (r *SendPrivateTxResponse) Err() error {
+	return checkRPCError(r.Error, r.Result)
+}
+
+// Err returns the error of a failed flashbots_getUserStats call, or nil.
+func (r *UserStatsResponse) Err() error {
+	return checkRPCError(r.Error, r.Result)
+}
+
+// CheckBlock reports, with an error wrapping ErrBlockMismatch, whether the
+// relay accepted the bundle for a different block than blockNumber. The
+// Flashbots relay only returns the bundle hash, in which case there is
+// nothing to compare and CheckBlock returns nil.
+func (r *SendBundleResponse) CheckBlock(blockNumber uint64) error {
+	if r.Result == nil || r.Result.BlockNumber == "" {
+		return nil
+	}
+
+	accepted, err := hexutil.DecodeUint64(r.Result.BlockNumber)
+	if err != nil {
+		return fmt.Errorf("invalid accepted block %q: %w", r.Result.BlockNumber, err)
+	}
+	if accepted != blockNumber {
+		return fmt.Errorf("%w: requested %d, accepted %d", ErrBlockMismatch, blockNumber, accepted)
+	}
+
+	return nil
+}
+
+// RevertedTxHashes returns the hashes of the simulated transactions that
+// reverted, in bundle order. Other failures such as a bad nonce are left
+// out, as allowing them to revert would not make the bundle land. The
+// result can be passed as RevertingTxHashes to a following SendBundle when
+// those reverts are acceptable.
+func (r *CallBundleResponse) RevertedTxHashes() []string {
+	if r.Result == nil {
+		return nil
+	}
+
+	var hashes []string
+	for _, tx := range r.Result.Results {
+		if tx.ErrorKind() == TxErrorRevert {
+			hashes = append(hashes, tx.TxHash)
+		}
+	}
+
+	return hashes
+}
+
+// TxHash returns the transaction hash accepted by the relay, or an error
+// when the relay answered with an error object or a malformed hash.
+func (r *SendPrivateTxResponse) TxHash() (string, error) {
+	if err := r.Err(); err != nil {
+		return "", err
+	}
+
+	if _, err := parseHash(r.Result); err != nil {
+		return "", err
+	}
+
+	return r.Result, nil
+}
+
+// TxHashTyped is TxHash as a common.Hash.
+func (r *SendPrivateTxResponse) TxHashTyped() (common.Hash, error) {
+	if err := r.Err(); err != nil {
+		return common.Hash{}, err
+	}
+	return parseHash(r.Result)
+}
+
+// BundleHashTyped returns the bundle hash reported by the relay as a common.Hash.
+func (r *SendBundleResponse) BundleHashTyped() (common.Hash, error) {
+	if err := r.Err(); err != nil {
+		return common.Hash{}, err
+	}
+	return parseHash(r.Result.BundleHash)
+}
+
+// BundleHashTyped returns the simulated bundle's hash as a common.Hash.
+func (r *CallBundleResponse) BundleHashTyped() (common.Hash, error) {
+	if err := r.Err(); err != nil {
+		return common.Hash{}, err
+	}
+	return parseHash(r.Result.BundleHash)
+}
+
+// StateRoot returns the state root after the simulated bundle. ok is false
+// when the relay does not report state roots, as the Flashbots relay does
+// not.
+func (r *CallBundleResponse) StateRoot() (root common.Hash, ok bool, err error) {
+	if err := r.Err(); err != nil {
+		return common.Hash{}, false, err
+	}
+	if r.Result.StateRoot == "" {
+		return common.Hash{}, false, nil
+	}
+
+	root, err = parseHash(r.Result.StateRoot)
+	return root, err == nil, err
+}
+
+// TxHashTyped returns the simulated transaction's hash as a common.Hash.
+func (r *TxResult) TxHashTyped() (common.Hash, error) {
+	return parseHash(r.TxHash)
+}
+
+// parseHash decodes a 0x prefixed 32 byte hash.
+func parseHash(hash string) (common.Hash, error) {
+	raw, err := hexutil.Decode(hash)
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("invalid hash %q: %w", hash, err)
+	}
+	if len(raw) != common.HashLength {
+		return common.Hash{}, fmt.Errorf("invalid hash %q: want %d bytes, got %d", hash, common.HashLength, len(raw))
+	}
+
+	return common.BytesToHash(raw), nil
+}
+
+// ValidateGasConsistency checks that the bundle's TotalGasUsed equals the
+// sum of the per-transaction GasUsed. A mismatch points at a parsing or
+// relay problem, so the gas figures should not be trusted.
+func (r *CallBundleResponse) ValidateGasConsistency() error {
+	if err := r.Err(); err != nil {
+		return err
+	}
+
+	var sum uint64
+	for _, tx := range r.Result.Results {
+		sum += tx.GasUsed
+	}
+	if sum != r.Result.TotalGasUsed {
+		return fmt.Errorf("totalGasUsed %d does not match the %d gas used by the transactions", r.Result.TotalGasUsed, sum)
+	}
+
+	return nil
+}
+
+// CoinbaseDiffWei returns the bundle's total payment to the coinbase in wei.
+func (r *CallBundleResponse) CoinbaseDiffWei() (*big.Int, error) {
+	if err := r.Err(); err != nil {
+		return nil, err
+	}
+	return parseQuantity(r.Result.CoinbaseDiff)
+}
+
+// CoinbaseDiffEth returns CoinbaseDiffWei as a decimal ETH string such as
+// "0.0123", without trailing zeros.
+func (r *CallBundleResponse) CoinbaseDiffEth() (string, error) {
+	wei, err := r.CoinbaseDiffWei()
+	if err != nil {
+		return "", err
+	}
+	return formatUnits(wei, 18), nil
+}
+
+// CoinbaseDiffGwei returns CoinbaseDiffWei as a decimal gwei string.
+func (r *CallBundleResponse) CoinbaseDiffGwei() (string, error) {
+	wei, err := r.CoinbaseDiffWei()
+	if err != nil {
+		return "", err
+	}
+	return formatUnits(wei, 9), nil
+}
+
+// IsProfitable reports whether the bundle's coinbase payment exceeds
+// subsidyWei, a flat amount paid on top of it, e.g. to the validator. A nil
+// subsidy counts as zero.
+func (r *CallBundleResponse) IsProfitable(subsidyWei *big.Int) (bool, error) {
+	diff, err := r.CoinbaseDiffWei()
+	if err != nil {
+		return false, err
+	}
+	if subsidyWei != nil {
+		diff.Sub(diff, subsidyWei)
+	}
+	return diff.Sign() > 0, nil
+}
+
+// BundleGasPriceBig returns the bundle's effective gas price in wei, its
+// coinbase payment divided by the gas it used, for ranking competing
+// bundles. A missing gas price is an error rather than zero.
+func (r *CallBundleResponse) BundleGasPriceBig() (*big.Int, error) {
+	if err := r.Err(); err != nil {
+		return nil, err
+	}
+	if r.Result.BundleGasPrice == "" {
+		return nil, errors.New("relay returned no bundle gas price")
+	}
+	return parseQuantity(r.Result.BundleGasPrice)
+}
+
+// GasPriceWei returns the gas price the transaction paid in wei.
+func (r *TxResult) GasPriceWei() (*big.Int, error) {
+	return parseQuantity(r.GasPrice)
+}
+
+// EffectivePriorityFee returns the priority fee per gas the transaction
+// effectively paid the coinbase in wei, including direct coinbase
+// transfers: its coinbase payment divided by its gas used.
+func (r *TxResult) EffectivePriorityFee() (*big.Int, error) {
+	diff, err := parseQuantity(r.CoinbaseDiff)
+	if err != nil {
+		return nil, err
+	}
+	if r.GasUsed == 0 {
+		return new(big.Int), nil
+	}
+	return diff.Div(diff, new(big.Int).SetUint64(r.GasUsed)), nil
+}
+
+// MaxFeePerGasWei returns the relay's maxFeePerGas in wei, or nil if the
+// relay did not return one.
+func (r *TxResult) MaxFeePerGasWei() (*big.Int, error) {
+	return parseOptionalQuantity(r.MaxFeePerGas)
+}
+
+// MaxPriorityFeePerGasWei returns the relay's maxPriorityFeePerGas in wei,
+// or nil if the relay did not return one.
+func (r *TxResult) MaxPriorityFeePerGasWei() (*big.Int, error) {
+	return parseOptionalQuantity(r.MaxPriorityFeePerGas)
+}
+
+func parseOptionalQuantity(quantity string) (*big.Int, error) {
+	if quantity == "" {
+		return nil, nil
+	}
+	return parseQuantity(quantity)
+}
+
+// formatUnits formats value as a decimal with the given number of decimals,
+// keeping full precision but dropping trailing zeros.
+func formatUnits(value *big.Int, decimals int) string {
+	digits := new(big.Int).Abs(value).String()
+	if len(digits) <= decimals {
+		digits = strings.Repeat("0", decimals-len(digits)+1) + digits
+	}
+
+	whole, fraction := digits[:len(digits)-decimals], strings.TrimRight(digits[len(digits)-decimals:], "0")
+	out := whole
+	if fraction != "" {
+		out += "." + fraction
+	}
+	if value.Sign() < 0 {
+		out = "-" + out
+	}
+
+	return out
+}
@@ -0,0 +1,21 @@
+package flashbot
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestRevertedTxHashes(t *testing.T) {
+	resp := &CallBundleResponse{Result: &CallResult{Results: []TxResult{
+		{TxHash: "0x01"},
+		{TxHash: "0x02", Error: "execution reverted"},
+		{TxHash: "0x03", Error: "nonce too low: address 0xab, tx: 3 state: 4"},
+		{TxHash: "0x04", Error: "out of gas"},
+		{TxHash: "0x05", Error: "Reverted"},
+	}}}
+
+	want := []string{"0x02", "0x05"}
+	if got := resp.RevertedTxHashes(); !reflect.DeepEqual(got, want) {
+		t.Errorf("RevertedTxHashes() = %v, want %v", got, want)
+	}
+}
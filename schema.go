@@ -0,0 +1,216 @@
+package flashbot
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// responseSchemaJSON describes the expected result of every relay method,
+// see validateResponse.
+//
+//go:embed responses.schema.json
+var responseSchemaJSON []byte
+
+// schema is the subset of JSON Schema the embedded response schemas use:
+// type, properties, required, items, pattern and $ref to a definition.
+type schema struct {
+	Ref        string             `json:"$ref"`
+	Type       schemaType         `json:"type"`
+	Properties map[string]*schema `json:"properties"`
+	Required   []string           `json:"required"`
+	Items      *schema            `json:"items"`
+	Pattern    string             `json:"pattern"`
+
+	pattern *regexp.Regexp
+}
+
+// schemaType is a JSON Schema type, given as one name or a list of names.
+type schemaType []string
+
+func (t *schemaType) UnmarshalJSON(data []byte) error {
+	var name string
+	if err := json.Unmarshal(data, &name); err == nil {
+		*t = schemaType{name}
+		return nil
+	}
+	return json.Unmarshal(data, (*[]string)(t))
+}
+
+type responseSchemas struct {
+	Definitions map[string]*schema `json:"definitions"`
+	Methods     map[string]*schema `json:"methods"`
+}
+
+var (
+	loadSchemasOnce sync.Once
+	loadedSchemas   *responseSchemas
+	loadSchemasErr  error
+)
+
+// schemas parses the embedded schemas once.
+func schemas() (*responseSchemas, error) {
+	loadSchemasOnce.Do(func() {
+		s := new(responseSchemas)
+		if loadSchemasErr = json.Unmarshal(responseSchemaJSON, s); loadSchemasErr != nil {
+			return
+		}
+		for _, def := range s.Definitions {
+			if loadSchemasErr = def.compile(); loadSchemasErr != nil {
+				return
+			}
+		}
+		for _, method := range s.Methods {
+			if loadSchemasErr = method.compile(); loadSchemasErr != nil {
+				return
+			}
+		}
+		loadedSchemas = s
+	})
+	return loadedSchemas, loadSchemasErr
+}
+
+func (s *schema) compile() error {
+	if s.Pattern != "" {
+		var err error
+		if s.pattern, err = regexp.Compile(s.Pattern); err != nil {
+			return err
+		}
+	}
+	for _, property := range s.Properties {
+		if err := property.compile(); err != nil {
+			return err
+		}
+	}
+	if s.Items != nil {
+		return s.Items.compile()
+	}
+	return nil
+}
+
+// validateResponse checks a JSON-RPC response of method against the
+// embedded schemas and returns every mismatch, each naming the offending
+// path. Methods without a schema only have their envelope checked.
+func validateResponse(method string, resp []byte) ([]string, error) {
+	s, err := schemas()
+	if err != nil {
+		return nil, fmt.Errorf("response schema: %w", err)
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(resp))
+	dec.UseNumber()
+	var envelope interface{}
+	if err := dec.Decode(&envelope); err != nil {
+		return nil, err
+	}
+
+	v := &schemaValidator{definitions: s.Definitions}
+	obj, ok := envelope.(map[string]interface{})
+	if !ok {
+		v.fail("response", "expected object, got %s", jsonType(envelope))
+		return v.mismatches, nil
+	}
+	if _, ok := obj["jsonrpc"].(string); !ok {
+		v.fail("jsonrpc", "expected string, got %s", jsonType(obj["jsonrpc"]))
+	}
+
+	if rpcErr, ok := obj["error"]; ok && rpcErr != nil {
+		v.validate("error", s.Definitions["error"], rpcErr)
+	} else if result, ok := obj["result"]; !ok {
+		v.fail("response", "missing result and error")
+	} else if resultSchema := s.Methods[method]; resultSchema != nil && result != nil {
+		v.validate("result", resultSchema, result)
+	}
+
+	return v.mismatches, nil
+}
+
+type schemaValidator struct {
+	definitions map[string]*schema
+	mismatches  []string
+}
+
+func (v *schemaValidator) fail(path, format string, args ...interface{}) {
+	v.mismatches = append(v.mismatches, path+": "+fmt.Sprintf(format, args...))
+}
+
+func (v *schemaValidator) validate(path string, s *schema, value interface{}) {
+	if s.Ref != "" {
+		def := v.definitions[strings.TrimPrefix(s.Ref, "#/definitions/")]
+		if def == nil {
+			v.fail(path, "unknown schema %s", s.Ref)
+			return
+		}
+		s = def
+	}
+
+	if len(s.Type) > 0 && !s.Type.matches(value) {
+		v.fail(path, "expected %s, got %s", strings.Join(s.Type, " or "), jsonType(value))
+		return
+	}
+
+	switch value := value.(type) {
+	case string:
+		if s.pattern != nil && !s.pattern.MatchString(value) {
+			v.fail(path, "%q does not match %s", value, s.Pattern)
+		}
+	case []interface{}:
+		if s.Items != nil {
+			for i, item := range value {
+				v.validate(fmt.Sprintf("%s[%d]", path, i), s.Items, item)
+			}
+		}
+	case map[string]interface{}:
+		for _, name := range s.Required {
+			if _, ok := value[name]; !ok {
+				v.fail(path+"."+name, "missing")
+			}
+		}
+		names := make([]string, 0, len(s.Properties))
+		for name := range s.Properties {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			if field, ok := value[name]; ok {
+				v.validate(path+"."+name, s.Properties[name], field)
+			}
+		}
+	}
+}
+
+func (t schemaType) matches(value interface{}) bool {
+	actual := jsonType(value)
+	for _, name := range t {
+		if name == actual || name == "number" && actual == "integer" {
+			return true
+		}
+	}
+	return false
+}
+
+// jsonType names the JSON Schema type of a value decoded with UseNumber.
+func jsonType(value interface{}) string {
+	switch value := value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case string:
+		return "string"
+	case json.Number:
+		if strings.ContainsAny(value.String(), ".eE") {
+			return "number"
+		}
+		return "integer"
+	case []interface{}:
+		return "array"
+	default:
+		return "object"
+	}
+}
@@ -0,0 +1,197 @@
+package flashbot
+
+import (
+	"crypto/ecdsa"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// Signer signs relay requests, allowing keys held outside the process
+// such as in an HSM or a remote signer.
+type Signer interface {
+	// Sign returns the 65 byte [R || S || V] secp256k1 signature of the
+	// 32 byte hash, with V being 0 or 1 as produced by crypto.Sign.
+	Sign(hash []byte) ([]byte, error)
+	// Address returns the address the relay attributes requests to.
+	Address() common.Address
+}
+
+// keySigner is the Signer backed by an in-memory private key.
+type keySigner struct {
+	key     *ecdsa.PrivateKey
+	address common.Address
+	hex     string
+}
+
+// NewKeySigner returns a Signer for privateKey.
+func NewKeySigner(privateKey *ecdsa.PrivateKey) Signer {
+	return newKeySigner(privateKey)
+}
+
+func newKeySigner(privateKey *ecdsa.PrivateKey) *keySigner {
+	address := crypto.PubkeyToAddress(privateKey.PublicKey)
+	return &keySigner{key: privateKey, address: address, hex: address.Hex()}
+}
+
+func (s *keySigner) Sign(hash []byte) ([]byte, error) {
+	return crypto.Sign(hash, s.key)
+}
+
+func (s *keySigner) Address() common.Address {
+	return s.address
+}
+
+// NewWithSigner returns a client for the relay network that signs with
+// signer rather than a PRIVATE_KEY from the environment. A WithSigner in
+// opts takes precedence.
+func NewWithSigner(relayRPC string, signer Signer, opts ...Option) *FlashbotLaunch {
+	rpc, _ := RelayDefaultRPC(relayRPC)
+
+	f := newClient(rpc, nil, append([]Option{WithSigner(signer)}, opts...))
+	f.setNetwork(relayRPC)
+
+	return f
+}
+
+// requestSigner returns the Signer of the next request: from the key pool
+// when configured, then an explicit Signer, then PrivateKey.
+func (f *FlashbotLaunch) requestSigner() Signer {
+	if f.keys != nil {
+		return f.keys.pick()
+	}
+	if f.signer != nil {
+		return f.signer
+	}
+	return f.privateKeySigner(f.PrivateKey)
+}
+
+// pooledSigner returns the key at index of the key pool, see
+// WithSignerIndex.
+func (f *FlashbotLaunch) pooledSigner(index int) (Signer, error) {
+	if f.keys == nil {
+		return nil, errors.New("signer index requires a key pool, see WithKeyPool")
+	}
+	if index < 0 || index >= len(f.keys.signers) {
+		return nil, fmt.Errorf("signer index %d out of range for %d pooled keys", index, len(f.keys.signers))
+	}
+	return f.keys.signers[index], nil
+}
+
+// accountSigner returns the Signer of the client's own account, which signs
+// transactions: an explicit Signer, otherwise PrivateKey. Key pools only
+// sign relay requests, so a client with neither has no account.
+func (f *FlashbotLaunch) accountSigner() (Signer, error) {
+	if f.signer != nil {
+		return f.signer, nil
+	}
+	if f.PrivateKey == nil {
+		return nil, errorNoAccount
+	}
+	return f.privateKeySigner(f.PrivateKey), nil
+}
+
+// privateKeySigner returns a cached Signer for key, so its address is only
+// derived once.
+func (f *FlashbotLaunch) privateKeySigner(key *ecdsa.PrivateKey) *keySigner {
+	f.signerMu.Lock()
+	defer f.signerMu.Unlock()
+
+	if signer, ok := f.keySigners[key]; ok {
+		return signer
+	}
+	if f.keySigners == nil {
+		f.keySigners = make(map[*ecdsa.PrivateKey]*keySigner)
+	}
+
+	signer := newKeySigner(key)
+	f.keySigners[key] = signer
+	return signer
+}
+
+// SignatureScheme selects what digest of the payload is signed for the
+// X-Flashbots-Signature header.
+type SignatureScheme int
+
+const (
+	// SchemeEIP191 signs the EIP-191 text hash of the hex encoded keccak256
+	// of the payload, as the Flashbots relay expects.
+	SchemeEIP191 SignatureScheme = iota
+	// SchemeRawKeccak signs the keccak256 of the payload directly, as some
+	// other builders expect.
+	SchemeRawKeccak
+)
+
+// signPayload returns the X-Flashbots-Signature header for payload: the
+// signer's address and its signature of the scheme's payload digest.
+func signPayload(payload []byte, signer Signer, scheme SignatureScheme) (string, error) {
+	signature, err := signer.Sign(payloadDigest(payload, scheme))
+	if err != nil {
+		return "", err
+	}
+
+	return signerHex(signer) + ":" + hexutil.Encode(signature), nil
+}
+
+// payloadDigest returns the hash of payload that scheme signs.
+func payloadDigest(payload []byte, scheme SignatureScheme) []byte {
+	hash := crypto.Keccak256(payload)
+	if scheme == SchemeEIP191 {
+		// "0x" + hex(keccak256(payload)), built on the stack rather than via hexutil.Encode.
+		var digest [2 + 2*32]byte
+		copy(digest[:], "0x")
+		hex.Encode(digest[2:], hash)
+		hash = accounts.TextHash(digest[:])
+	}
+	return hash
+}
+
+// RecoverSignerFromHeader checks an X-Flashbots-Signature header the way
+// the relay does: it splits the header into address and signature, recovers
+// the signer of the EIP-191 digest of payload and returns the address if
+// it matches the claimed one. Signatures with V of 27 or 28 are accepted.
+func RecoverSignerFromHeader(payload []byte, header string) (common.Address, error) {
+	claimed, sigHex, ok := strings.Cut(header, ":")
+	if !ok || !common.IsHexAddress(claimed) {
+		return common.Address{}, fmt.Errorf("malformed signature header %q", header)
+	}
+
+	signature, err := hexutil.Decode(sigHex)
+	if err != nil {
+		return common.Address{}, fmt.Errorf("signature: %w", err)
+	}
+	if len(signature) != crypto.SignatureLength {
+		return common.Address{}, fmt.Errorf("signature is %d bytes, want %d", len(signature), crypto.SignatureLength)
+	}
+	if signature[crypto.RecoveryIDOffset] >= 27 {
+		signature = append([]byte(nil), signature...)
+		signature[crypto.RecoveryIDOffset] -= 27
+	}
+
+	pub, err := crypto.SigToPub(payloadDigest(payload, SchemeEIP191), signature)
+	if err != nil {
+		return common.Address{}, err
+	}
+
+	recovered := crypto.PubkeyToAddress(*pub)
+	if recovered != common.HexToAddress(claimed) {
+		return recovered, fmt.Errorf("signature recovers to %s, header claims %s", recovered.Hex(), claimed)
+	}
+
+	return recovered, nil
+}
+
+// signerHex returns the checksummed address of signer, reusing the cached
+// form of in-memory keys.
+func signerHex(signer Signer) string {
+	if s, ok := signer.(*keySigner); ok {
+		return s.hex
+	}
+	return signer.Address().Hex()
+}
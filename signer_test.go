@@ -0,0 +1,120 @@
+package flashbot
+
+import (
+	"crypto/ecdsa"
+	"math/big"
+	"os"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// signPayloadUnoptimized is the signing path before the digest was built
+// in a stack buffer and the signer address cached, kept as the baseline of
+// BenchmarkSignPayload.
+func signPayloadUnoptimized(payload []byte, key *ecdsa.PrivateKey) (string, error) {
+	signature, err := crypto.Sign(accounts.TextHash([]byte(hexutil.Encode(crypto.Keccak256(payload)))), key)
+	if err != nil {
+		return "", err
+	}
+	return crypto.PubkeyToAddress(key.PublicKey).Hex() + ":" + hexutil.Encode(signature), nil
+}
+
+// benchmarkPayload returns a realistic two transaction eth_sendBundle body.
+func benchmarkPayload(tb testing.TB) []byte {
+	tb.Helper()
+
+	payload, err := os.ReadFile("testdata/send_bundle.json")
+	if err != nil {
+		tb.Fatal(err)
+	}
+	return payload
+}
+
+func testKey(tb testing.TB) *ecdsa.PrivateKey {
+	tb.Helper()
+
+	key, err := crypto.HexToECDSA(testKeyHex)
+	if err != nil {
+		tb.Fatal(err)
+	}
+	return key
+}
+
+func TestSignPayload(t *testing.T) {
+	payload := benchmarkPayload(t)
+	key := testKey(t)
+
+	header, err := signPayload(payload, NewKeySigner(key), SchemeEIP191)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want, err := signPayloadUnoptimized(payload, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if header != want {
+		t.Errorf("header = %s, want %s", header, want)
+	}
+
+	signer, err := RecoverSignerFromHeader(payload, header)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if signer != crypto.PubkeyToAddress(key.PublicKey) {
+		t.Errorf("recovered %s, want %s", signer.Hex(), crypto.PubkeyToAddress(key.PublicKey).Hex())
+	}
+
+	if _, err := RecoverSignerFromHeader(append(payload, ' '), header); err == nil {
+		t.Error("header verified for a different payload")
+	}
+}
+
+func TestNewWithSigner(t *testing.T) {
+	signer := NewKeySigner(testKey(t))
+	other, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	f := NewWithSigner("goerli", signer)
+	if f.network != "goerli" || f.chainID == nil || f.chainID.Cmp(big.NewInt(5)) != 0 {
+		t.Errorf("network %q chain id %v, want goerli and 5", f.network, f.chainID)
+	}
+	if f.requestSigner() != signer {
+		t.Error("default signer not used")
+	}
+
+	override := NewKeySigner(other)
+	if f := NewWithSigner("mainnet", signer, WithSigner(override)); f.requestSigner() != override {
+		t.Error("WithSigner in opts overwritten")
+	}
+}
+
+func BenchmarkSignPayload(b *testing.B) {
+	payload := benchmarkPayload(b)
+	signer := NewKeySigner(testKey(b))
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := signPayload(payload, signer, SchemeEIP191); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkSignPayloadUnoptimized(b *testing.B) {
+	payload := benchmarkPayload(b)
+	key := testKey(b)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := signPayloadUnoptimized(payload, key); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
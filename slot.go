@@ -0,0 +1,75 @@
+package flashbot
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// ##########
+// slot targeting
+// ##########
+
+// SlotTime returns the start of slot on the client's network, see
+// NetworkBeaconGenesis.
+func (f *FlashbotLaunch) SlotTime(slot uint64) (time.Time, error) {
+	genesis, err := NetworkBeaconGenesis(f.network)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("slot targeting: %w", err)
+	}
+	return genesis.Add(time.Duration(slot) * slotTime), nil
+}
+
+// SendBundleForSlot sends the bundle so it can only land in the block of
+// the given beacon chain slot.
+//
+// Slots and blocks differ: every 12 seconds has a slot, but a slot holds a
+// block only if its proposer built one, so block numbers fall behind slot
+// numbers with every missed slot. eth_sendBundle only takes a block number,
+// so the slot is translated: the bundle targets the block number the slot
+// gets if no slot before it is missed, and its minTimestamp and
+// maxTimestamp are both set to the slot's start, the timestamp of its
+// block. The bundle thus never lands in another slot. If a slot in between
+// is missed the slot's block has a lower number and the bundle is not
+// included; send it again once the gap is known. It requires NodeRpc and a
+// client created for a known network.
+func (f *FlashbotLaunch) SendBundleForSlot(transactions []string, slot uint64, opts ...BundleOption) (*SendBundleResponse, error) {
+	at, err := f.SlotTime(slot)
+	if err != nil {
+		return nil, err
+	}
+
+	blockNumber, err := f.slotBlockNumber(at)
+	if err != nil {
+		return nil, err
+	}
+
+	opts = append(opts, WithMinTime(at), WithMaxTime(at))
+	return f.SendBundle(transactions, blockNumber, opts...)
+}
+
+// slotBlockNumber returns the number of the block at time at, assuming no
+// slot until then is missed.
+func (f *FlashbotLaunch) slotBlockNumber(at time.Time) (uint64, error) {
+	result, err := f.callNode("eth_getBlockByNumber", "latest", false)
+	if err != nil {
+		return 0, err
+	}
+
+	var header struct {
+		Number    hexutil.Uint64 `json:"number"`
+		Timestamp hexutil.Uint64 `json:"timestamp"`
+	}
+	if err := json.Unmarshal(result, &header); err != nil {
+		return 0, err
+	}
+
+	latest := time.Unix(int64(header.Timestamp), 0)
+	if !at.After(latest) {
+		return 0, fmt.Errorf("slot at %s is not after the latest block %d at %s", at.UTC().Format(time.RFC3339), header.Number, latest.UTC().Format(time.RFC3339))
+	}
+
+	return uint64(header.Number) + uint64(at.Sub(latest)/slotTime), nil
+}
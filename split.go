@@ -0,0 +1,104 @@
+package flashbot
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+)
+
+// ErrGroupTooLarge is returned by SplitBundle when an atomic group holds
+// more transactions than fit into a single bundle.
+var ErrGroupTooLarge = errors.New("atomic group exceeds bundle size limit")
+
+// SplitStrategy selects how SplitBundle packs groups into bundles.
+type SplitStrategy int
+
+const (
+	// SplitOrdered fills bundles with the groups in their given order, so
+	// every transaction lands in a bundle no earlier than the ones before it.
+	SplitOrdered SplitStrategy = iota
+	// SplitPacked places each group, largest first, into the first bundle
+	// with room left. It yields the fewest bundles but drops the ordering
+	// between groups.
+	SplitPacked
+)
+
+// SplitBundle splits groups of raw signed transactions into bundles of at
+// most maxTxs transactions. Each group is atomic: it is never split, and a
+// group larger than maxTxs fails with ErrGroupTooLarge. Pass every
+// transaction as its own group when nothing has to stay together.
+//
+// The resulting bundles are independent of each other. The relay executes
+// and reverts each one on its own, any of them may land without the others,
+// and they may be included in different blocks or in a different order.
+// Only transactions within the same group keep the all-or-nothing
+// guarantee of a single bundle.
+func SplitBundle(groups [][]string, maxTxs int, strategy SplitStrategy) ([][]string, error) {
+	if maxTxs < 1 {
+		return nil, errors.New("bundle size limit must be greater than zero")
+	}
+
+	for i, group := range groups {
+		if len(group) < 1 {
+			return nil, fmt.Errorf("group %d: %w", i, errorTransaction)
+		}
+		if len(group) > maxTxs {
+			return nil, fmt.Errorf("group %d: %w: %d transactions, limit %d", i, ErrGroupTooLarge, len(group), maxTxs)
+		}
+	}
+
+	switch strategy {
+	case SplitOrdered:
+		return splitOrdered(groups, maxTxs), nil
+	case SplitPacked:
+		return splitPacked(groups, maxTxs), nil
+	default:
+		return nil, fmt.Errorf("unknown split strategy %d", strategy)
+	}
+}
+
+func splitOrdered(groups [][]string, maxTxs int) [][]string {
+	var bundles [][]string
+	var current []string
+	for _, group := range groups {
+		if len(current)+len(group) > maxTxs {
+			bundles = append(bundles, current)
+			current = nil
+		}
+		current = append(current, group...)
+	}
+	if len(current) > 0 {
+		bundles = append(bundles, current)
+	}
+
+	return bundles
+}
+
+func splitPacked(groups [][]string, maxTxs int) [][]string {
+	order := make([]int, len(groups))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		return len(groups[order[a]]) > len(groups[order[b]])
+	})
+
+	var bundles [][]string
+	for _, i := range order {
+		group := groups[i]
+
+		placed := false
+		for j := range bundles {
+			if len(bundles[j])+len(group) <= maxTxs {
+				bundles[j] = append(bundles[j], group...)
+				placed = true
+				break
+			}
+		}
+		if !placed {
+			bundles = append(bundles, append([]string(nil), group...))
+		}
+	}
+
+	return bundles
+}
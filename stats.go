@@ -0,0 +1,337 @@
+package flashbot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// ErrUnknownBundle is returned by WaitForBundleStats when the relay has no
+// record of the bundle, e.g. because the hash or block is wrong.
+var ErrUnknownBundle = errors.New("relay does not know the bundle")
+
+// #############
+// bundleStats
+// #############
+type BundleStatsParams struct {
+	BundleHash  string `json:"bundleHash"`
+	BlockNumber string `json:"blockNumber"`
+}
+
+type BundleStatsResponse struct {
+	ID      uint         `json:"id"`
+	Version string       `json:"jsonrpc"`
+	Result  *BundleStats `json:"result"`
+	Error   *errorResult `json:"error"`
+}
+
+type BundleStats struct {
+	IsSimulated    bool   `json:"isSimulated"`
+	IsSentToMiners bool   `json:"isSentToMiners"`
+	IsHighPriority bool   `json:"isHighPriority"`
+	SimulatedAt    string `json:"simulatedAt,omitempty"`
+	SubmittedAt    string `json:"submittedAt,omitempty"`
+	SentToMinersAt string `json:"sentToMinersAt,omitempty"`
+}
+
+// BundleState is the progress of a bundle on the relay.
+type BundleState int
+
+const (
+	// BundleUnknown means the relay has no record of the bundle.
+	BundleUnknown BundleState = iota
+	// BundlePending means the relay received the bundle but has not
+	// simulated it yet.
+	BundlePending
+	// BundleSimulated means the relay simulated the bundle.
+	BundleSimulated
+)
+
+func (s BundleState) String() string {
+	switch s {
+	case BundlePending:
+		return "pending"
+	case BundleSimulated:
+		return "simulated"
+	default:
+		return "unknown"
+	}
+}
+
+// State tells apart unknown bundles, which the relay answers with empty
+// stats, from bundles that were submitted but not simulated yet.
+func (s *BundleStats) State() BundleState {
+	switch {
+	case s == nil:
+		return BundleUnknown
+	case s.IsSimulated:
+		return BundleSimulated
+	case s.SubmittedAt != "":
+		return BundlePending
+	default:
+		return BundleUnknown
+	}
+}
+
+// Err returns the error of a failed flashbots_getBundleStats call, or nil.
+func (r *BundleStatsResponse) Err() error {
+	return checkRPCError(r.Error, r.Result)
+}
+
+// GetBundleStats returns the relay's stats for the bundle submitted for blockNumber.
+func (f *FlashbotLaunch) GetBundleStats(bundleHash string, blockNumber uint64) (*BundleStatsResponse, error) {
+	return f.GetBundleStatsContext(context.Background(), bundleHash, blockNumber)
+}
+
+// GetBundleStatsContext is GetBundleStats giving up when ctx is done.
+func (f *FlashbotLaunch) GetBundleStatsContext(ctx context.Context, bundleHash string, blockNumber uint64) (*BundleStatsResponse, error) {
+	args := BundleStatsParams{
+		BundleHash:  bundleHash,
+		BlockNumber: HextoBlockNumber(blockNumber),
+	}
+
+	resp, err := f.requestRPCContext(ctx, MethodGetBundleStats, args)
+	if err != nil {
+		return nil, err
+	}
+	bundleStatsResp := new(BundleStatsResponse)
+	if err := f.decodeResponse(MethodGetBundleStats, resp, bundleStatsResp); err != nil {
+		return nil, err
+	}
+
+	return bundleStatsResp, nil
+}
+
+type BundleStatsV2Response struct {
+	ID      uint           `json:"id"`
+	Version string         `json:"jsonrpc"`
+	Result  *BundleStatsV2 `json:"result"`
+	Error   *errorResult   `json:"error"`
+}
+
+// BundleStatsV2 are the stats of flashbots_getBundleStatsV2, which report
+// per builder when the bundle was considered and sealed.
+type BundleStatsV2 struct {
+	IsHighPriority         bool           `json:"isHighPriority"`
+	IsSimulated            bool           `json:"isSimulated"`
+	SimulatedAt            string         `json:"simulatedAt,omitempty"`
+	ReceivedAt             string         `json:"receivedAt,omitempty"`
+	ConsideredByBuildersAt []BuilderEvent `json:"consideredByBuildersAt"`
+	SealedByBuildersAt     []BuilderEvent `json:"sealedByBuildersAt"`
+}
+
+// BuilderEvent is the time a builder, identified by its BLS public key,
+// acted on the bundle.
+type BuilderEvent struct {
+	Pubkey    string `json:"pubkey"`
+	Timestamp string `json:"timestamp"`
+}
+
+// Err returns the error of a failed flashbots_getBundleStatsV2 call, or nil.
+func (r *BundleStatsV2Response) Err() error {
+	return checkRPCError(r.Error, r.Result)
+}
+
+// GetBundleStatsV2 returns the relay's v2 stats for the bundle submitted
+// for blockNumber, including which builders considered and sealed it.
+func (f *FlashbotLaunch) GetBundleStatsV2(bundleHash string, blockNumber uint64) (*BundleStatsV2Response, error) {
+	args := BundleStatsParams{
+		BundleHash:  bundleHash,
+		BlockNumber: HextoBlockNumber(blockNumber),
+	}
+
+	resp, err := f.requestRPC(MethodGetBundleStatsV2, args)
+	if err != nil {
+		return nil, err
+	}
+	bundleStatsResp := new(BundleStatsV2Response)
+	if err := f.decodeResponse(MethodGetBundleStatsV2, resp, bundleStatsResp); err != nil {
+		return nil, err
+	}
+
+	return bundleStatsResp, nil
+}
+
+// WaitForBundleStats polls GetBundleStats every interval until the bundle
+// is simulated or ctx is done. It gives up at once with ErrUnknownBundle
+// when the relay does not know the bundle, instead of waiting for ctx.
+func (f *FlashbotLaunch) WaitForBundleStats(ctx context.Context, bundleHash string, blockNumber uint64, interval time.Duration) (*BundleStatsResponse, BundleState, error) {
+	if interval <= 0 {
+		return nil, BundleUnknown, fmt.Errorf("poll interval must be positive, got %s", interval)
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		resp, err := f.GetBundleStatsContext(ctx, bundleHash, blockNumber)
+		if err != nil {
+			return nil, BundleUnknown, err
+		}
+		if err := resp.Err(); err != nil {
+			return resp, BundleUnknown, err
+		}
+
+		switch state := resp.Result.State(); state {
+		case BundleSimulated:
+			return resp, state, nil
+		case BundleUnknown:
+			return resp, state, ErrUnknownBundle
+		}
+
+		select {
+		case <-ctx.Done():
+			return resp, BundlePending, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// SendBundleAndCheck sends the bundle, waits delay and then queries its
+// stats once, to tell right away whether the relay simulated it. A block
+// number of 0 targets the next block. The send response is returned even
+// when the stats query fails.
+func (f *FlashbotLaunch) SendBundleAndCheck(transactions []string, blockNumber uint64, delay time.Duration, opts ...BundleOption) (*SendBundleResponse, *BundleStatsResponse, error) {
+	blockNumber, err := f.resolveBlock(blockNumber)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	resp, err := f.SendBundle(transactions, blockNumber, opts...)
+	if err != nil {
+		return resp, nil, err
+	}
+	if err := resp.Err(); err != nil {
+		return resp, nil, err
+	}
+
+	time.Sleep(delay)
+
+	stats, err := f.GetBundleStats(resp.Result.BundleHash, blockNumber)
+	if err != nil {
+		return resp, nil, err
+	}
+
+	return resp, stats, stats.Err()
+}
+
+// userStatsConcurrency bounds the parallel requests of GetUserStatsRange.
+const userStatsConcurrency = 4
+
+// maxUserStatsSamples caps the samples of one GetUserStatsRange call, so a
+// wide range with a small step cannot issue an unbounded number of
+// requests.
+const maxUserStatsSamples = 1 << 10
+
+// GetUserStatsRange samples GetUserStats at fromBlock, fromBlock+step, ...
+// up to toBlock, at most maxUserStatsSamples blocks. Responses are in block
+// order; a sample that failed is left nil and its error is included in the
+// returned error.
+func (f *FlashbotLaunch) GetUserStatsRange(fromBlock, toBlock, step uint64) ([]*UserStatsResponse, error) {
+	if step == 0 {
+		return nil, errors.New("step must be greater than zero")
+	}
+	if fromBlock > toBlock {
+		return nil, fmt.Errorf("fromBlock %d is after toBlock %d", fromBlock, toBlock)
+	}
+	if (toBlock-fromBlock)/step >= maxUserStatsSamples {
+		return nil, fmt.Errorf("blocks %d to %d every %d blocks exceed the limit of %d samples", fromBlock, toBlock, step, maxUserStatsSamples)
+	}
+
+	var blocks []uint64
+	for block := fromBlock; block <= toBlock; block += step {
+		blocks = append(blocks, block)
+		if toBlock-block < step {
+			break
+		}
+	}
+
+	responses := make([]*UserStatsResponse, len(blocks))
+	errs := make([]error, len(blocks))
+
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, userStatsConcurrency)
+	for i, block := range blocks {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int, block uint64) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			resp, err := f.GetUserStats(block)
+			if err != nil {
+				errs[i] = fmt.Errorf("block %d: %w", block, err)
+				return
+			}
+			responses[i] = resp
+		}(i, block)
+	}
+	wg.Wait()
+
+	return responses, errors.Join(errs...)
+}
+
+// EstimateInclusionLikelihood returns a rough 0..1 score of how likely the
+// relay is to favour the searcher's bundles, from its user stats at
+// blockNumber. It is a best-effort heuristic, not a prediction:
+//
+//	0.1 base
+//	+0.5 when the searcher is high priority
+//	+0.2 when it paid miners in the last 7 days
+//	+0.2 when it paid miners in the last day
+func (f *FlashbotLaunch) EstimateInclusionLikelihood(blockNumber uint64) (float64, error) {
+	resp, err := f.GetUserStats(blockNumber)
+	if err != nil {
+		return 0, err
+	}
+	if err := resp.Err(); err != nil {
+		return 0, err
+	}
+
+	stats := resp.Result
+	score := 0.1
+	if stats.IsHighPriority {
+		score += 0.5
+	}
+
+	last7d, err := parseQuantity(stats.Last7dMinerPayments)
+	if err != nil {
+		return 0, fmt.Errorf("last_7d_miner_payments: %w", err)
+	}
+	if last7d.Sign() > 0 {
+		score += 0.2
+	}
+
+	last1d, err := parseQuantity(stats.Last1dMinerPayments)
+	if err != nil {
+		return 0, fmt.Errorf("last_1d_miner_payments: %w", err)
+	}
+	if last1d.Sign() > 0 {
+		score += 0.2
+	}
+
+	return score, nil
+}
+
+// parseQuantity parses a decimal or 0x prefixed hex quantity as returned by
+// the relay. An empty string is zero.
+func parseQuantity(quantity string) (*big.Int, error) {
+	if quantity == "" {
+		return new(big.Int), nil
+	}
+	if strings.HasPrefix(quantity, "0x") {
+		return hexutil.DecodeBig(quantity)
+	}
+
+	value, ok := new(big.Int).SetString(quantity, 10)
+	if !ok {
+		return nil, fmt.Errorf("invalid quantity %q", quantity)
+	}
+	return value, nil
+}
@@ -0,0 +1,76 @@
+package flashbot
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWaitForBundleStats(t *testing.T) {
+	bundleHash := "0x" + strings.Repeat("ab", 32)
+
+	t.Run("non-positive interval", func(t *testing.T) {
+		f := newTestClient(t, newTestRelay(t, `{"jsonrpc":"2.0","id":1,"result":{}}`).URL)
+		for _, interval := range []time.Duration{0, -time.Second} {
+			if _, _, err := f.WaitForBundleStats(context.Background(), bundleHash, 17000000, interval); err == nil {
+				t.Errorf("interval %s accepted", interval)
+			}
+		}
+	})
+
+	t.Run("canceled mid-request", func(t *testing.T) {
+		relay := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			io.Copy(io.Discard, r.Body)
+			<-r.Context().Done()
+		}))
+		defer relay.Close()
+		f := newTestClient(t, relay.URL)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+
+		start := time.Now()
+		_, _, err := f.WaitForBundleStats(ctx, bundleHash, 17000000, time.Second)
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("err = %v, want context.DeadlineExceeded", err)
+		}
+		if elapsed := time.Since(start); elapsed > time.Second {
+			t.Errorf("returned after %s, want the request cut short", elapsed)
+		}
+	})
+}
+
+func TestGetUserStatsRangeLimit(t *testing.T) {
+	tests := []struct {
+		name      string
+		from, to  uint64
+		step      uint64
+		samples   int
+		wantError bool
+	}{
+		{name: "single block", from: 17000000, to: 17000000, step: 1, samples: 1},
+		{name: "at the limit", from: 17000000, to: 17000000 + maxUserStatsSamples - 1, step: 1, samples: maxUserStatsSamples},
+		{name: "past the limit", from: 17000000, to: 17000000 + maxUserStatsSamples, step: 1, wantError: true},
+		{name: "whole range", from: 0, to: math.MaxUint64, step: 1, wantError: true},
+		{name: "whole range, wide step", from: 1, to: math.MaxUint64, step: math.MaxUint64 / 4, samples: 5},
+	}
+
+	f := newTestClient(t, newTestRelay(t, userStatsResponse).URL)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			responses, err := f.GetUserStatsRange(tt.from, tt.to, tt.step)
+			if (err != nil) != tt.wantError {
+				t.Fatalf("GetUserStatsRange() = %v, want error %t", err, tt.wantError)
+			}
+			if len(responses) != tt.samples {
+				t.Errorf("got %d samples, want %d", len(responses), tt.samples)
+			}
+		})
+	}
+}
@@ -0,0 +1,77 @@
+package flashbot
+
+import "strings"
+
+// TxErrorKind classifies the error of a simulated transaction.
+type TxErrorKind int
+
+const (
+	// TxErrorNone means the transaction succeeded.
+	TxErrorNone TxErrorKind = iota
+	// TxErrorRevert means the transaction reverted.
+	TxErrorRevert
+	// TxErrorNonceTooLow means the nonce was already used.
+	TxErrorNonceTooLow
+	// TxErrorNonceTooHigh means earlier nonces of the sender are missing.
+	TxErrorNonceTooHigh
+	// TxErrorInsufficientFunds means the sender cannot pay for gas and value.
+	TxErrorInsufficientFunds
+	// TxErrorGas covers running out of gas and gas limits that are too low
+	// or above the block's.
+	TxErrorGas
+	// TxErrorOther is any error not recognised above.
+	TxErrorOther
+)
+
+func (k TxErrorKind) String() string {
+	switch k {
+	case TxErrorNone:
+		return "none"
+	case TxErrorRevert:
+		return "revert"
+	case TxErrorNonceTooLow:
+		return "nonce too low"
+	case TxErrorNonceTooHigh:
+		return "nonce too high"
+	case TxErrorInsufficientFunds:
+		return "insufficient funds"
+	case TxErrorGas:
+		return "gas"
+	default:
+		return "other"
+	}
+}
+
+// txErrorPatterns maps substrings of EVM and relay error messages to their
+// kind, most specific first.
+var txErrorPatterns = []struct {
+	substr string
+	kind   TxErrorKind
+}{
+	{"nonce too low", TxErrorNonceTooLow},
+	{"nonce too high", TxErrorNonceTooHigh},
+	{"insufficient funds", TxErrorInsufficientFunds},
+	{"insufficient balance", TxErrorInsufficientFunds},
+	{"out of gas", TxErrorGas},
+	{"intrinsic gas too low", TxErrorGas},
+	{"gas limit reached", TxErrorGas},
+	{"exceeds block gas limit", TxErrorGas},
+	{"revert", TxErrorRevert},
+}
+
+// ErrorKind classifies Error, so callers can branch on common simulation
+// failures without matching strings themselves.
+func (r *TxResult) ErrorKind() TxErrorKind {
+	if r.Error == "" {
+		return TxErrorNone
+	}
+
+	msg := strings.ToLower(r.Error)
+	for _, p := range txErrorPatterns {
+		if strings.Contains(msg, p.substr) {
+			return p.kind
+		}
+	}
+
+	return TxErrorOther
+}
@@ -0,0 +1,60 @@
+package flashbot
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"time"
+)
+
+// Warmup opens a connection to the relay ahead of the first submission, so
+// the TCP and TLS handshakes are not paid on the hot path. It sends an
+// unsigned HEAD request whose status is ignored.
+func (f *FlashbotLaunch) Warmup(ctx context.Context) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, f.Rpc, nil)
+	if err != nil {
+		return err
+	}
+	if f.basicAuth != nil {
+		req.SetBasicAuth(f.basicAuth.user, f.basicAuth.pass)
+	}
+
+	resp, err := f.httpClient().Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	// Drain the body so the connection goes back to the idle pool.
+	_, err = io.Copy(ioutil.Discard, resp.Body)
+	return err
+}
+
+// KeepWarm calls Warmup every interval until ctx is done, keeping an idle
+// relay connection open for continuous per-block submission. Run it in its
+// own goroutine with an interval below the idle connection timeout, see
+// WithConnectionPool. Failed warmups are logged in debug mode and retried
+// on the next tick. It returns ctx's error once ctx is done, or an error
+// right away when interval is not positive.
+func (f *FlashbotLaunch) KeepWarm(ctx context.Context, interval time.Duration) error {
+	if interval <= 0 {
+		return fmt.Errorf("warmup interval must be positive, got %s", interval)
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := f.Warmup(ctx); err != nil && ctx.Err() == nil && f.debug {
+			f.logf("flashbot: relay warmup failed: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,115 @@
+package flashbot
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+const userStatsResponse = `{"jsonrpc":"2.0","id":1,"result":{"is_high_priority":false}}`
+
+// newTLSRelay starts a TLS relay answering user stats and counting the
+// connections opened to it.
+func newTLSRelay(tb testing.TB) (*httptest.Server, *int32) {
+	tb.Helper()
+
+	var conns int32
+	relay := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.Copy(io.Discard, r.Body)
+		io.WriteString(w, userStatsResponse)
+	}))
+	relay.Config.ConnState = func(_ net.Conn, state http.ConnState) {
+		if state == http.StateNew {
+			atomic.AddInt32(&conns, 1)
+		}
+	}
+	relay.StartTLS()
+	tb.Cleanup(relay.Close)
+
+	return relay, &conns
+}
+
+// newTLSClient returns a client with its own connection pool, trusting the
+// relay's certificate.
+func newTLSClient(tb testing.TB, relay *httptest.Server) (*FlashbotLaunch, *http.Transport) {
+	tb.Helper()
+
+	transport := relay.Client().Transport.(*http.Transport).Clone()
+	key := testKey(tb)
+	return newClient(relay.URL, key, []Option{WithHTTPClient(&http.Client{Transport: transport})}), transport
+}
+
+func TestWarmupReusesConnection(t *testing.T) {
+	relay, conns := newTLSRelay(t)
+	f, _ := newTLSClient(t, relay)
+
+	if err := f.Warmup(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 3; i++ {
+		if _, err := f.GetUserStats(17000000); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if n := atomic.LoadInt32(conns); n != 1 {
+		t.Errorf("opened %d connections, want 1 reused after Warmup", n)
+	}
+}
+
+func TestKeepWarm(t *testing.T) {
+	relay, _ := newTLSRelay(t)
+	f, _ := newTLSClient(t, relay)
+
+	for _, interval := range []time.Duration{0, -time.Second} {
+		if err := f.KeepWarm(context.Background(), interval); err == nil {
+			t.Errorf("interval %s accepted", interval)
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := f.KeepWarm(ctx, 5*time.Millisecond); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("err = %v, want context.DeadlineExceeded", err)
+	}
+}
+
+// BenchmarkSubmissionLatency measures one signed relay request from a
+// fresh client, cold versus after Warmup opened the TLS connection.
+func BenchmarkSubmissionLatency(b *testing.B) {
+	relay, _ := newTLSRelay(b)
+
+	for _, warm := range []bool{false, true} {
+		name := "cold"
+		if warm {
+			name = "warm"
+		}
+		b.Run(name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				b.StopTimer()
+				f, transport := newTLSClient(b, relay)
+				if warm {
+					if err := f.Warmup(context.Background()); err != nil {
+						b.Fatal(err)
+					}
+				}
+				b.StartTimer()
+
+				if _, err := f.GetUserStats(17000000); err != nil {
+					b.Fatal(err)
+				}
+
+				b.StopTimer()
+				transport.CloseIdleConnections()
+				b.StartTimer()
+			}
+		})
+	}
+}